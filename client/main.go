@@ -16,6 +16,8 @@ func main() {
 
         MainWindow = App.NewWindow("Quiz Battle 🕹️")
         MainWindow.Resize(fyne.NewSize(420, 720))
+        // Fermer la fenêtre fait quitter la partie en cours
+        MainWindow.SetOnClosed(LeaveCurrentGame)
 
         InitNetwork()
         ShowLoginScreen()
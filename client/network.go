@@ -2,18 +2,22 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net"
 	"quiz-app-fyne/shared"
+	"strings"
 
-	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/widget"
+	"fyne.io/fyne/v2/dialog"
 )
 
 var serverAddr *net.UDPAddr
 var conn *net.UDPConn
 
+// Jeton de session reçu à la connexion, joint aux requêtes qui engagent le compte
+var SessionToken string
+
 func InitNetwork() {
 	addr, err := net.ResolveUDPAddr("udp", "127.0.0.1:9000")
 	if err != nil {
@@ -30,7 +34,8 @@ func InitNetwork() {
 }
 
 func ListenServer() {
-	buffer := make([]byte, 4096)
+	// Assez grand pour une liste de salles complète
+	buffer := make([]byte, 65535)
 
 	for {
 		n, _, err := conn.ReadFromUDP(buffer)
@@ -52,20 +57,116 @@ func ListenServer() {
 				ID:    payload.UserID,
 				Email: payload.Email,
 			}
+			SessionToken = payload.Token
 
 			ShowModeSelectionScreen()
+			// Retrouver une partie interrompue (déconnexion, redémarrage du serveur)
+			send(shared.Message{
+				Type:    shared.MsgResume,
+				Payload: shared.ResumePayload{UserID: CurrentUser.ID, Token: SessionToken},
+			})
 
-		case shared.MsgCreateGame:
+		case shared.MsgCreateGame, shared.MsgJoinGame, shared.MsgMatchFound:
 			data, _ := json.Marshal(msg.Payload)
-			var payload map[string]string
+			var payload shared.GameJoinedPayload
 			json.Unmarshal(data, &payload)
 
-			CurrentUser.GameCode = payload["game_code"]
+			CurrentUser.GameCode = payload.GameCode
+			CurrentSettings = payload.Settings
+			CurrentHostID = payload.HostID
+			Spectating = false
+			lastAnswerFeedback = ""
+			wagerInProgress = false
+			suddenDeathWatching = false
+
+			if payload.Mode == "multi" {
+				ShowLobby(shared.LobbyUpdatePayload{
+					GameCode: payload.GameCode,
+					HostID:   payload.HostID,
+					State:    shared.GameStateLobby,
+					Settings: payload.Settings,
+				})
+			}
+
+		case shared.MsgLobbyUpdate:
+			data, _ := json.Marshal(msg.Payload)
+			var lp shared.LobbyUpdatePayload
+			json.Unmarshal(data, &lp)
+
+			CurrentHostID = lp.HostID
+			ShowLobby(lp)
+
+		case shared.MsgQueueStatus:
+			data, _ := json.Marshal(msg.Payload)
+			var qs shared.QueueStatusPayload
+			json.Unmarshal(data, &qs)
+
+			if qs.InQueue {
+				ShowQueueScreen(qs)
+			} else {
+				ShowLobbyScreen()
+			}
+
+		case shared.MsgGameHistory:
+			data, _ := json.Marshal(msg.Payload)
+			var hp shared.GameHistoryPayload
+			json.Unmarshal(data, &hp)
+
+			ShowGameHistory(hp.Games)
+
+		case shared.MsgReplay:
+			data, _ := json.Marshal(msg.Payload)
+			var rp shared.ReplayPayload
+			json.Unmarshal(data, &rp)
+
+			ReceiveReplayPart(rp)
+
+		case shared.MsgListRooms:
+			data, _ := json.Marshal(msg.Payload)
+			var rp shared.RoomListPayload
+			json.Unmarshal(data, &rp)
+
+			ShowRoomList(rp.Rooms)
+
+		case shared.MsgKicked:
+			data, _ := json.Marshal(msg.Payload)
+			var kp shared.GameErrorPayload
+			json.Unmarshal(data, &kp)
 
-			if payload["mode"] == "multi" {
-				ShowLobbyWithGameCode(payload["game_code"])
+			CurrentUser.GameCode = ""
+			dialog.ShowInformation("Exclu", kp.Message, MainWindow)
+			ShowLobbyScreen()
+
+		case shared.MsgGameState:
+			data, _ := json.Marshal(msg.Payload)
+			var sp shared.GameStatePayload
+			json.Unmarshal(data, &sp)
+
+			switch sp.State {
+			case shared.GameStateLobby:
+				SetLobbyStatus("En attente des joueurs... " + sp.Reason)
+			case shared.GameStateReveal:
+				ShowRoundReveal(sp)
+			case shared.GameStateAbandoned:
+				CurrentUser.GameCode = ""
+				dialog.ShowInformation("Partie interrompue", sp.Reason, MainWindow)
+				ShowModeSelectionScreen()
 			}
 
+		case shared.MsgCountdown:
+			data, _ := json.Marshal(msg.Payload)
+			var cp shared.CountdownPayload
+			json.Unmarshal(data, &cp)
+
+			SetLobbyStatus(fmt.Sprintf("⏳ Lancement dans %ds", cp.Seconds))
+
+		case shared.MsgGameError:
+			data, _ := json.Marshal(msg.Payload)
+			var payload shared.GameErrorPayload
+			json.Unmarshal(data, &payload)
+
+			dialog.ShowError(errors.New(payload.Message), MainWindow)
+
 		case shared.MsgQuestion:
 			data, _ := json.Marshal(msg.Payload)
 			var qp shared.QuestionPayload
@@ -75,8 +176,44 @@ func ListenServer() {
 				qp.Question.Text,
 				qp.Question.Options,
 				qp.Question.ID,
+				qp.PowerUps,
 			)
 
+		case shared.MsgWagerPrompt:
+			data, _ := json.Marshal(msg.Payload)
+			var wp shared.WagerPromptPayload
+			json.Unmarshal(data, &wp)
+
+			ShowWagerScreen(wp)
+
+		case shared.MsgWagerPlaced:
+			data, _ := json.Marshal(msg.Payload)
+			var wp shared.WagerPlacedPayload
+			json.Unmarshal(data, &wp)
+
+			ShowWagerPlaced(wp)
+
+		case shared.MsgWagerReveal:
+			data, _ := json.Marshal(msg.Payload)
+			var rp shared.WagerRevealPayload
+			json.Unmarshal(data, &rp)
+
+			ShowWagerReveal(rp)
+
+		case shared.MsgAnswerResult:
+			data, _ := json.Marshal(msg.Payload)
+			var ar shared.AnswerResultPayload
+			json.Unmarshal(data, &ar)
+
+			ShowAnswerResult(ar)
+
+		case shared.MsgPowerUpResult:
+			data, _ := json.Marshal(msg.Payload)
+			var pr shared.PowerUpResultPayload
+			json.Unmarshal(data, &pr)
+
+			ShowPowerUpResult(pr)
+
 		case shared.MsgRiddle:
 			data, _ := json.Marshal(msg.Payload)
 			var rp shared.RiddlePayload
@@ -84,16 +221,89 @@ func ListenServer() {
 
 			ShowRiddleScreen(rp.Text)
 
+		case shared.MsgRiddleHint:
+			data, _ := json.Marshal(msg.Payload)
+			var hp shared.RiddleHintPayload
+			json.Unmarshal(data, &hp)
+
+			ShowRiddleHint(hp)
+
+		case shared.MsgRiddleResult:
+			data, _ := json.Marshal(msg.Payload)
+			var rr shared.RiddleResultPayload
+			json.Unmarshal(data, &rr)
+
+			ShowRiddleResult(rr)
+
+		case shared.MsgScoreUpdate:
+			data, _ := json.Marshal(msg.Payload)
+			var sp shared.ScoreUpdatePayload
+			json.Unmarshal(data, &sp)
+
+			UpdateScoreboard(sp.Scores, sp.Teams)
+
+		case shared.MsgPing:
+			data, _ := json.Marshal(msg.Payload)
+			var pp shared.PingPayload
+			json.Unmarshal(data, &pp)
+
+			// Réponse immédiate : le serveur mesure la latence pour départager le buzzer
+			if CurrentUser != nil {
+				send(shared.Message{
+					Type:    shared.MsgPong,
+					Payload: shared.PongPayload{UserID: CurrentUser.ID, Seq: pp.Seq},
+				})
+			}
+
+		case shared.MsgBuzzerResult:
+			data, _ := json.Marshal(msg.Payload)
+			var bp shared.BuzzerResultPayload
+			json.Unmarshal(data, &bp)
+
+			ShowBuzzerResult(bp)
+
+		case shared.MsgEliminated:
+			data, _ := json.Marshal(msg.Payload)
+			var ep shared.EliminationPayload
+			json.Unmarshal(data, &ep)
+
+			ShowElimination(ep)
+
+		case shared.MsgSuddenDeath:
+			data, _ := json.Marshal(msg.Payload)
+			var sp shared.SuddenDeathPayload
+			json.Unmarshal(data, &sp)
+
+			ShowSuddenDeath(sp)
+
 		case shared.MsgGameOver:
 			data, _ := json.Marshal(msg.Payload)
 			var gp shared.GameOverPayload
 			json.Unmarshal(data, &gp)
 
+			CurrentUser.GameCode = ""
+			Spectating = false
+			suddenDeathWatching = false
+
+			var teams []string
+			for _, t := range gp.Teams {
+				teams = append(teams, fmt.Sprintf("%s : %d", teamLabel(t.Team), t.Score))
+			}
 			var results []string
 			for _, r := range gp.Results {
-				results = append(results, fmt.Sprintf("%s : %d", r.Email, r.Score))
+				line := fmt.Sprintf("%s : %d", r.Email, r.Score)
+				if r.Team > 0 {
+					line += " · " + shared.TeamName(r.Team)
+				}
+				if r.Placement == 1 && len(gp.Results) > 1 {
+					line += " 🏆"
+				}
+				if r.Rating > 0 {
+					line += fmt.Sprintf(" (⭐ %d, %+d)", r.Rating, r.RatingDelta)
+				}
+				results = append(results, line)
 			}
-			ShowResults(results)
+			ShowResults(teams, results)
 		case "GAME_START":
 			ShowWaitingRoom()
 		}
@@ -119,26 +329,66 @@ func SendLogin(email, password string) {
 	})
 }
 
-func SendCreateGame(userID int, mode string) {
+func SendCreateGame(userID int, mode string, settings *shared.GameSettings, password string, public bool) {
 	send(shared.Message{
 		Type: shared.MsgCreateGame,
-		Payload: map[string]interface{}{
-			"user_id": userID,
-			"mode":    mode,
+		Payload: shared.CreateGamePayload{
+			UserID:   userID,
+			Mode:     mode,
+			Settings: settings,
+			Password: password,
+			Public:   public,
 		},
 	})
 }
 
-func SendJoinGame(code string, userID int) {
+func SendJoinGame(code, password string, userID int) {
 	send(shared.Message{
 		Type: shared.MsgJoinGame,
-		Payload: map[string]interface{}{
-			"user_id":   userID,
-			"game_code": code,
+		Payload: shared.JoinGamePayload{
+			UserID:   userID,
+			GameCode: strings.ToUpper(strings.TrimSpace(code)),
+			Password: password,
+		},
+	})
+}
+
+func SendQueue(join bool) {
+	msgType := shared.MsgQueue
+	if !join {
+		msgType = shared.MsgUnqueue
+	}
+	send(shared.Message{
+		Type:    msgType,
+		Payload: shared.QueuePayload{UserID: CurrentUser.ID},
+	})
+}
+
+func SendGameHistoryRequest() {
+	send(shared.Message{
+		Type:    shared.MsgGameHistory,
+		Payload: shared.GameHistoryRequestPayload{UserID: CurrentUser.ID, Token: SessionToken},
+	})
+}
+
+func SendReplayRequest(gameID string) {
+	send(shared.Message{
+		Type: shared.MsgReplay,
+		Payload: shared.ReplayRequestPayload{
+			UserID: CurrentUser.ID,
+			Token:  SessionToken,
+			GameID: gameID,
 		},
 	})
 }
 
+func SendListRooms() {
+	send(shared.Message{
+		Type:    shared.MsgListRooms,
+		Payload: map[string]interface{}{},
+	})
+}
+
 func SendAnswer(questionID int, choice int) {
 	send(shared.Message{
 		Type: shared.MsgAnswer,
@@ -150,6 +400,27 @@ func SendAnswer(questionID int, choice int) {
 	})
 }
 
+func SendUsePowerUp(questionID int, powerUp string) {
+	send(shared.Message{
+		Type: shared.MsgUsePowerUp,
+		Payload: shared.UsePowerUpPayload{
+			UserID:     CurrentUser.ID,
+			QuestionID: questionID,
+			PowerUp:    powerUp,
+		},
+	})
+}
+
+func SendPlaceWager(amount int) {
+	send(shared.Message{
+		Type: shared.MsgPlaceWager,
+		Payload: shared.PlaceWagerPayload{
+			UserID: CurrentUser.ID,
+			Amount: amount,
+		},
+	})
+}
+
 func SendRiddleAnswer(text string) {
 	send(shared.Message{
 		Type: shared.MsgRiddleAnswer,
@@ -163,24 +434,132 @@ func SendRiddleAnswer(text string) {
 func RequestHint(level int) {
 	send(shared.Message{
 		Type: shared.MsgRequestRiddleHint,
-		Payload: map[string]interface{}{
-			"user_id":   CurrentUser.ID,
-			"hint_type": level,
+		Payload: shared.RiddleHintRequestPayload{
+			UserID:   CurrentUser.ID,
+			HintType: level,
+		},
+	})
+}
+
+func SendStartGame() {
+	send(shared.Message{
+		Type: shared.MsgStartGame,
+		Payload: shared.StartGamePayload{
+			UserID:   CurrentUser.ID,
+			Token:    SessionToken,
+			GameCode: CurrentUser.GameCode,
+		},
+	})
+}
+
+func SendSetReady(ready bool) {
+	send(shared.Message{
+		Type: shared.MsgSetReady,
+		Payload: shared.SetReadyPayload{
+			UserID:   CurrentUser.ID,
+			Token:    SessionToken,
+			GameCode: CurrentUser.GameCode,
+			Ready:    ready,
+		},
+	})
+}
+
+func SendKickPlayer(targetID int) {
+	send(shared.Message{
+		Type: shared.MsgKickPlayer,
+		Payload: shared.HostActionPayload{
+			UserID:   CurrentUser.ID,
+			Token:    SessionToken,
+			GameCode: CurrentUser.GameCode,
+			TargetID: targetID,
+		},
+	})
+}
+
+func SendTransferHost(targetID int) {
+	send(shared.Message{
+		Type: shared.MsgTransferHost,
+		Payload: shared.HostActionPayload{
+			UserID:   CurrentUser.ID,
+			Token:    SessionToken,
+			GameCode: CurrentUser.GameCode,
+			TargetID: targetID,
+		},
+	})
+}
+
+func SendLockRoom(locked bool) {
+	send(shared.Message{
+		Type: shared.MsgLockRoom,
+		Payload: shared.LockRoomPayload{
+			UserID:   CurrentUser.ID,
+			Token:    SessionToken,
+			GameCode: CurrentUser.GameCode,
+			Locked:   locked,
+		},
+	})
+}
+
+func SendSetTeam(targetID, team int) {
+	send(shared.Message{
+		Type: shared.MsgSetTeam,
+		Payload: shared.SetTeamPayload{
+			UserID:   CurrentUser.ID,
+			Token:    SessionToken,
+			GameCode: CurrentUser.GameCode,
+			TargetID: targetID,
+			Team:     team,
 		},
 	})
 }
 
-var waitLabel *widget.Label
+func SendBalanceTeams() {
+	send(shared.Message{
+		Type: shared.MsgBalanceTeams,
+		Payload: shared.BalanceTeamsPayload{
+			UserID:   CurrentUser.ID,
+			Token:    SessionToken,
+			GameCode: CurrentUser.GameCode,
+		},
+	})
+}
 
-func ShowLobbyWithGameCode(code string) {
-	codeLabel := widget.NewLabel("Code de la salle : " + code)
-	waitLabel = widget.NewLabel("En attente des joueurs...")
+func SendSetPublic(public bool) {
+	send(shared.Message{
+		Type: shared.MsgSetPublic,
+		Payload: shared.SetPublicPayload{
+			UserID:   CurrentUser.ID,
+			Token:    SessionToken,
+			GameCode: CurrentUser.GameCode,
+			Public:   public,
+		},
+	})
+}
 
-	MainWindow.SetContent(
-		container.NewVBox(
-			widget.NewLabel("🎮 Lobby"),
-			codeLabel,
-			waitLabel,
-		),
-	)
+// LeaveCurrentGame prévient le serveur que le joueur quitte sa partie
+func LeaveCurrentGame() {
+	if CurrentUser == nil || CurrentUser.GameCode == "" {
+		return
+	}
+	send(shared.Message{
+		Type: shared.MsgLeaveGame,
+		Payload: shared.LeaveGamePayload{
+			UserID:   CurrentUser.ID,
+			Token:    SessionToken,
+			GameCode: CurrentUser.GameCode,
+		},
+	})
+	CurrentUser.GameCode = ""
+}
+
+func SendShortenCountdown(seconds int) {
+	send(shared.Message{
+		Type: shared.MsgShortenCountdown,
+		Payload: shared.ShortenCountdownPayload{
+			UserID:   CurrentUser.ID,
+			Token:    SessionToken,
+			GameCode: CurrentUser.GameCode,
+			Seconds:  seconds,
+		},
+	})
 }
@@ -1,57 +1,323 @@
 package main
 
 import (
+	"fmt"
+	"quiz-app-fyne/shared"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
 
-func ShowQuestionScreen(question string, options []string, questionID int) {
+// Spectating est vrai quand le joueur a été éliminé (mode élimination) :
+// il voit la suite de la partie sans pouvoir répondre
+var Spectating bool
+
+// spectatorBanner rappelle au joueur éliminé, ou qui regarde une mort subite, qu'il ne peut pas répondre
+func spectatorBanner() fyne.CanvasObject {
+	switch {
+	case Spectating:
+		return widget.NewLabelWithStyle("👀 Éliminé : tu regardes la suite en spectateur", fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+	case suddenDeathWatching:
+		return widget.NewLabelWithStyle("👀 Mort subite : tu regardes le départage", fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+	}
+	return container.NewVBox()
+}
+
+// Question affichée et ses boutons, pour les résultats du buzzer et les jokers
+var currentQuestionID int
+var questionButtons []*widget.Button
+var questionFeedback *widget.Label
+
+// Verdict de la dernière réponse, rappelé une fois à l'écran de la question suivante
+var lastAnswerFeedback string
+var answerFeedback *widget.Label
+
+// Boutons des jokers de la question affichée, par type
+var powerUpButtons map[string]*widget.Button
+
+// Libellés des jokers, dans l'ordre de shared.PowerUpKinds
+var powerUpLabels = map[string]string{
+	shared.PowerUpFiftyFifty: "50/50 ✂️",
+	shared.PowerUpDouble:     "Points x2 ✖️",
+	shared.PowerUpSkip:       "Passer ⏭️",
+	shared.PowerUpExtraTime:  "Temps bonus ⏳",
+}
+
+// powerUpBar affiche les jokers restants (vide si la partie se joue sans jokers)
+func powerUpBar(questionID int, inventory map[string]int) fyne.CanvasObject {
+	powerUpButtons = make(map[string]*widget.Button)
+	if len(inventory) == 0 || Spectating {
+		return container.NewVBox()
+	}
+	var buttons []fyne.CanvasObject
+	for _, kind := range shared.PowerUpKinds {
+		powerUp := kind
+		btn := widget.NewButton("", func() {
+			SendUsePowerUp(questionID, powerUp)
+		})
+		powerUpButtons[kind] = btn
+		buttons = append(buttons, btn)
+	}
+	updatePowerUps(inventory)
+	return container.NewGridWithColumns(len(buttons), buttons...)
+}
+
+// updatePowerUps met à jour le nombre de jokers restants
+func updatePowerUps(inventory map[string]int) {
+	for kind, btn := range powerUpButtons {
+		btn.SetText(fmt.Sprintf("%s (%d)", powerUpLabels[kind], inventory[kind]))
+		if inventory[kind] <= 0 {
+			btn.Disable()
+		}
+	}
+}
+
+func ShowQuestionScreen(question string, options []string, questionID int, powerUps map[string]int) {
 	questionLabel := widget.NewLabelWithStyle(
 		question,
 		fyne.TextAlignCenter,
 		fyne.TextStyle{Bold: true},
 	)
+	currentQuestionID = questionID
+	questionFeedback = widget.NewLabelWithStyle("", fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+	answerFeedback = widget.NewLabelWithStyle("", fyne.TextAlignCenter, fyne.TextStyle{})
+	if lastAnswerFeedback != "" {
+		answerFeedback.SetText("Réponse précédente : " + lastAnswerFeedback)
+		lastAnswerFeedback = ""
+	}
+	questionButtons = nil
 
 	var buttons []fyne.CanvasObject
 	for i, opt := range options {
 		index := i
 		btn := widget.NewButton(opt, func() {
 			SendAnswer(questionID, index)
+			// Question finale à mise : une seule réponse, verdict à la révélation
+			if wagerInProgress {
+				for _, b := range questionButtons {
+					b.Disable()
+				}
+				questionFeedback.SetText("🤞 Réponse enregistrée, verdict à la révélation des mises")
+			}
 		})
+		if Spectating || suddenDeathWatching {
+			btn.Disable()
+		}
+		questionButtons = append(questionButtons, btn)
 		buttons = append(buttons, btn)
 	}
 
 	MainWindow.SetContent(
 		container.NewVBox(
+			spectatorBanner(),
 			questionLabel,
 			container.NewGridWithRows(2, buttons...),
+			powerUpBar(questionID, powerUps),
+			questionFeedback,
+			answerFeedback,
+			LiveScoreboard(),
+			LeaveButton(),
 		),
 	)
 }
 
+// ShowBuzzerResult affiche une réponse retenue au buzzer : mauvaise réponse d'un
+// joueur (exclu de la question) ou fin de la question avec son gagnant
+func ShowBuzzerResult(bp shared.BuzzerResultPayload) {
+	if questionFeedback == nil || bp.QuestionID != currentQuestionID {
+		return
+	}
+	mine := CurrentUser != nil && bp.UserID == CurrentUser.ID
+
+	var text string
+	switch {
+	case bp.Closed && bp.Correct && mine && bp.Multiplier > 1:
+		text = fmt.Sprintf("🔔 Tu as buzzé le premier ! +%d pts (combo ×%g, série de %d)", bp.Points, bp.Multiplier, bp.Streak)
+	case bp.Closed && bp.Correct && mine:
+		text = fmt.Sprintf("🔔 Tu as buzzé le premier ! +%d pts", bp.Points)
+	case bp.Closed && bp.Correct:
+		text = fmt.Sprintf("🔔 %s a buzzé le premier (réponse %s)", bp.Username, bp.Answer)
+	case bp.Closed:
+		text = fmt.Sprintf("⏱️ Personne n'a trouvé (réponse %s)", bp.Answer)
+	case mine:
+		text = fmt.Sprintf("❌ Mauvaise réponse (%d pts) : tu ne peux plus répondre", bp.Points)
+	default:
+		text = fmt.Sprintf("❌ %s s'est trompé (%d pts)", bp.Username, bp.Points)
+	}
+	questionFeedback.SetText(text)
+
+	if bp.Closed || mine {
+		for _, btn := range questionButtons {
+			btn.Disable()
+		}
+	}
+}
+
+// ShowAnswerResult affiche le verdict d'une réponse avec la série en cours
+func ShowAnswerResult(ar shared.AnswerResultPayload) {
+	text := "❌ Mauvaise réponse"
+	if ar.Correct {
+		text = fmt.Sprintf("✅ Bonne réponse ! +%d pts", ar.Points)
+		if ar.Multiplier > 1 {
+			text += fmt.Sprintf(" (combo ×%g)", ar.Multiplier)
+		}
+	} else if ar.Points < 0 {
+		text += fmt.Sprintf(" (%d pts)", ar.Points)
+	}
+	if ar.Streak >= 2 {
+		text += fmt.Sprintf(" · 🔥 série de %d", ar.Streak)
+	}
+	lastAnswerFeedback = text
+	if answerFeedback != nil {
+		answerFeedback.SetText(text)
+	}
+	// Une seule réponse par question
+	if ar.QuestionID == currentQuestionID {
+		for _, btn := range questionButtons {
+			btn.Disable()
+		}
+		for _, btn := range powerUpButtons {
+			btn.Disable()
+		}
+	}
+}
+
+// ShowPowerUpResult applique l'effet d'un joker accepté par le serveur
+func ShowPowerUpResult(pr shared.PowerUpResultPayload) {
+	if questionFeedback == nil || pr.QuestionID != currentQuestionID {
+		return
+	}
+	updatePowerUps(pr.Inventory)
+	if btn, ok := powerUpButtons[pr.PowerUp]; ok && pr.PowerUp != shared.PowerUpSkip {
+		btn.Disable()
+	}
+
+	switch pr.PowerUp {
+	case shared.PowerUpFiftyFifty:
+		for _, choice := range pr.Removed {
+			if choice >= 0 && choice < len(questionButtons) {
+				questionButtons[choice].Disable()
+			}
+		}
+		questionFeedback.SetText("✂️ Deux mauvaises réponses retirées")
+	case shared.PowerUpDouble:
+		questionFeedback.SetText("✖️ Points doublés sur cette question")
+	case shared.PowerUpExtraTime:
+		questionFeedback.SetText(fmt.Sprintf("⏳ +%ds pour répondre", pr.ExtraSeconds))
+	case shared.PowerUpSkip:
+		for _, btn := range questionButtons {
+			btn.Disable()
+		}
+		for _, btn := range powerUpButtons {
+			btn.Disable()
+		}
+		questionFeedback.SetText("⏭️ Question passée")
+	}
+}
+
+// Verdict de la dernière réponse à la devinette
+var riddleFeedback *widget.Label
+var riddleSubmit *widget.Button
+
+// Indices reçus pour la devinette en cours
+var riddleHintsBox *fyne.Container
+var riddleHintButtons []*widget.Button
+
 func ShowRiddleScreen(text string) {
+	riddleFeedback = widget.NewLabelWithStyle("", fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+	riddleHintsBox = container.NewVBox()
+
 	answer := widget.NewEntry()
 	answer.SetPlaceHolder("Ta réponse...")
 
 	submit := widget.NewButtonWithIcon("Valider ✅", theme.ConfirmIcon(), func() {
 		SendRiddleAnswer(answer.Text)
 	})
+	riddleSubmit = submit
 
-	hint1 := widget.NewButton("Indice -25 pts 💡", func() {
+	costs := CurrentSettings.HintCosts
+	hint1 := widget.NewButton(fmt.Sprintf("Indice -%d pts 💡", costs[0]), func() {
 		RequestHint(1)
 	})
-	hint2 := widget.NewButton("Indice -50 pts 💡", func() {
+	hint2 := widget.NewButton(fmt.Sprintf("Indice -%d pts 💡", costs[1]), func() {
 		RequestHint(2)
 	})
+	// L'indice 2 ne s'achète qu'après l'indice 1
+	hint2.Disable()
+	riddleHintButtons = []*widget.Button{hint1, hint2}
+	if Spectating {
+		answer.Disable()
+		submit.Disable()
+		hint1.Disable()
+	}
 
 	MainWindow.SetContent(
 		container.NewVBox(
+			spectatorBanner(),
 			widget.NewLabelWithStyle(text, fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
 			answer,
 			submit,
+			riddleFeedback,
 			container.NewGridWithColumns(2, hint1, hint2),
+			riddleHintsBox,
+			LiveScoreboard(),
+			LeaveButton(),
 		),
 	)
 }
+
+// ShowRiddleResult affiche le verdict du serveur sur la dernière réponse
+func ShowRiddleResult(rr shared.RiddleResultPayload) {
+	if riddleFeedback == nil {
+		return
+	}
+	var text string
+	switch rr.Result {
+	case shared.RiddleCorrect:
+		text = fmt.Sprintf("🎉 Bonne réponse ! +%d pts", rr.Points)
+	case shared.RiddleClose:
+		text = "🤏 Presque ! Vérifie l'orthographe"
+	case shared.RiddleLocked:
+		text = "🔒 Tu ne peux plus répondre"
+	default:
+		text = "❌ Mauvaise réponse"
+	}
+	if !rr.Solved {
+		text += fmt.Sprintf(" (%d essai(s) restant(s))", rr.AttemptsLeft)
+	}
+	riddleFeedback.SetText(text)
+
+	if rr.Solved || rr.AttemptsLeft <= 0 {
+		riddleSubmit.Disable()
+	}
+}
+
+// ShowRiddleHint affiche un indice reçu (un indice déjà affiché n'est pas dupliqué)
+func ShowRiddleHint(hp shared.RiddleHintPayload) {
+	if riddleHintsBox == nil || hp.Level < 1 || hp.Level > len(riddleHintButtons) {
+		return
+	}
+	if len(riddleHintsBox.Objects) < hp.Level {
+		riddleHintsBox.Add(widget.NewLabel(fmt.Sprintf("💡 Indice %d : %s", hp.Level, hp.Text)))
+	}
+
+	riddleHintButtons[hp.Level-1].SetText(fmt.Sprintf("Indice %d ✔", hp.Level))
+	if hp.Level < len(riddleHintButtons) {
+		riddleHintButtons[hp.Level].Enable()
+	}
+}
+
+// LeaveButton permet de quitter la partie en cours (après confirmation)
+func LeaveButton() fyne.CanvasObject {
+	return widget.NewButtonWithIcon("Quitter la partie", theme.LogoutIcon(), func() {
+		dialog.ShowConfirm("Quitter", "Quitter la partie en cours ?", func(ok bool) {
+			if ok {
+				LeaveCurrentGame()
+				ShowModeSelectionScreen()
+			}
+		}, MainWindow)
+	})
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"quiz-app-fyne/shared"
 
 	"fyne.io/fyne/v2"
@@ -11,17 +12,31 @@ import (
 
 var CurrentUser *shared.User
 
+// Paramètres de la partie en cours, reçus à la création ou à l'arrivée dans la salle
+var CurrentSettings = shared.DefaultGameSettings()
+var CurrentHostID int
+
 func ShowLobbyScreen() {
 	codeEntry := widget.NewEntry()
 	codeEntry.SetPlaceHolder("Code de la partie")
+	passwordEntry := widget.NewPasswordEntry()
+	passwordEntry.SetPlaceHolder("Mot de passe (salle privée)")
 
 	createBtn := widget.NewButtonWithIcon("Créer une partie ➕", theme.ContentAddIcon(), func() {
-		SendCreateGame(CurrentUser.ID, "multi")
+		ShowGameSettingsScreen()
+	})
+
+	queueBtn := widget.NewButtonWithIcon("Partie rapide ⚡", theme.MediaFastForwardIcon(), func() {
+		SendQueue(true)
+	})
+
+	browseBtn := widget.NewButtonWithIcon("Salles publiques 🔎", theme.SearchIcon(), func() {
+		ShowRoomBrowser()
 	})
 
 	joinBtn := widget.NewButtonWithIcon("Rejoindre 🎯", theme.MailSendIcon(), func() {
 		if codeEntry.Text != "" {
-			SendJoinGame(codeEntry.Text, CurrentUser.ID)
+			SendJoinGame(codeEntry.Text, passwordEntry.Text, CurrentUser.ID)
 		}
 	})
 
@@ -29,10 +44,145 @@ func ShowLobbyScreen() {
 		container.NewCenter(
 			container.NewVBox(
 				widget.NewLabelWithStyle("🕹️ Lobby", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+				queueBtn,
 				createBtn,
+				browseBtn,
 				codeEntry,
+				passwordEntry,
 				joinBtn,
 			),
 		),
 	)
 }
+
+// Texte d'état du lobby (attente, compte à rebours), conservé entre deux rafraîchissements
+var lobbyStatus = "En attente des joueurs..."
+var waitLabel *widget.Label
+
+// SetLobbyStatus met à jour la ligne d'état du lobby
+func SetLobbyStatus(text string) {
+	lobbyStatus = text
+	if waitLabel != nil {
+		waitLabel.SetText(text)
+	}
+}
+
+// ShowLobby affiche la salle d'attente : joueurs, statut prêt et contrôles de l'hôte
+func ShowLobby(lobby shared.LobbyUpdatePayload) {
+	isHost := CurrentUser.ID == lobby.HostID
+
+	codeLabel := widget.NewLabel("Code de la salle : " + lobby.GameCode)
+	if lobby.Private {
+		codeLabel.SetText(codeLabel.Text + " 🔑")
+	}
+	if lobby.Locked {
+		codeLabel.SetText(codeLabel.Text + " 🔒")
+	}
+	waitLabel = widget.NewLabel(lobbyStatus)
+
+	players := container.NewVBox()
+	ready := false
+	teams := lobby.Settings.Teams
+	var teamOptions []string
+	for team := 1; team <= teams; team++ {
+		teamOptions = append(teamOptions, shared.TeamName(team))
+	}
+	currentTeam := -1
+	for _, p := range lobby.Players {
+		player := p
+		line := player.Username
+		if player.IsHost {
+			line = "👑 " + line
+		}
+		if player.Ready {
+			line += " ✅"
+		} else {
+			line += " ⏳"
+		}
+		if player.UserID == CurrentUser.ID {
+			ready = player.Ready
+		}
+		// Les joueurs arrivent triés par équipe : un titre avant chaque équipe
+		if teams > 0 && player.Team != currentTeam {
+			currentTeam = player.Team
+			players.Add(widget.NewLabelWithStyle("🏳️ "+teamLabel(player.Team), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+		}
+
+		row := container.NewHBox(widget.NewLabel(line))
+		if isHost && teams > 0 {
+			teamSelect := widget.NewSelect(teamOptions, nil)
+			teamSelect.SetSelected(shared.TeamName(player.Team))
+			teamSelect.OnChanged = func(name string) {
+				for team := 1; team <= teams; team++ {
+					if shared.TeamName(team) == name && team != player.Team {
+						SendSetTeam(player.UserID, team)
+					}
+				}
+			}
+			row.Add(teamSelect)
+		}
+		if isHost && player.UserID != CurrentUser.ID {
+			row.Add(widget.NewButtonWithIcon("", theme.ContentRemoveIcon(), func() {
+				SendKickPlayer(player.UserID)
+			}))
+			row.Add(widget.NewButton("👑", func() {
+				SendTransferHost(player.UserID)
+			}))
+		}
+		players.Add(row)
+	}
+
+	readyCheck := widget.NewCheck("Je suis prêt", func(checked bool) {
+		SendSetReady(checked)
+	})
+	readyCheck.Checked = ready
+
+	content := container.NewVBox(
+		widget.NewLabelWithStyle("🎮 Lobby", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		codeLabel,
+		widget.NewCard("", "⚙️ Paramètres", widget.NewLabel(settingsSummary(lobby.Settings))),
+		widget.NewCard("", fmt.Sprintf("👥 Joueurs (%d)", len(lobby.Players)), players),
+		readyCheck,
+		waitLabel,
+	)
+
+	if isHost {
+		lockCheck := widget.NewCheck("Verrouiller la salle", func(checked bool) {
+			SendLockRoom(checked)
+		})
+		lockCheck.Checked = lobby.Locked
+
+		publicCheck := widget.NewCheck("Salle publique", func(checked bool) {
+			SendSetPublic(checked)
+		})
+		publicCheck.Checked = lobby.Public
+
+		content.Add(lockCheck)
+		content.Add(publicCheck)
+		if teams > 0 {
+			content.Add(widget.NewButton("Équilibrer les équipes ⚖️", func() {
+				SendBalanceTeams()
+			}))
+		}
+		content.Add(widget.NewButtonWithIcon("Lancer maintenant 🚀", theme.MediaPlayIcon(), func() {
+			SendStartGame()
+		}))
+		if lobby.State == shared.GameStateCountdown {
+			content.Add(widget.NewButton("Lancer dans 5s ⏩", func() {
+				SendShortenCountdown(5)
+			}))
+		}
+	}
+
+	content.Add(LeaveButton())
+
+	MainWindow.SetContent(container.NewVScroll(content))
+}
+
+// teamLabel nomme une équipe pour l'affichage
+func teamLabel(team int) string {
+	if name := shared.TeamName(team); name != "" {
+		return "Équipe " + name
+	}
+	return "Sans équipe"
+}
@@ -9,18 +9,23 @@ import (
 
 func ShowModeSelectionScreen() {
 	solo := widget.NewButtonWithIcon("🎮 Solo", theme.MediaPlayIcon(), func() {
-		SendCreateGame(CurrentUser.ID, "solo")
+		SendCreateGame(CurrentUser.ID, "solo", nil, "", false)
 	})
 
 	multi := widget.NewButtonWithIcon("👥 Multijoueur", theme.AccountIcon(), func() {
 		ShowLobbyScreen()
 	})
 
+	history := widget.NewButtonWithIcon("📜 Mes parties", theme.HistoryIcon(), func() {
+		SendGameHistoryRequest()
+	})
+
 	MainWindow.SetContent(
 		container.NewVBox(
 			widget.NewLabelWithStyle("Choisir le mode", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
 			solo,
 			multi,
+			history,
 		),
 	)
 }
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"quiz-app-fyne/shared"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ShowQueueScreen affiche l'attente dans la file de partie rapide
+func ShowQueueScreen(qs shared.QueueStatusPayload) {
+	cancelBtn := widget.NewButtonWithIcon("Annuler", theme.CancelIcon(), func() {
+		SendQueue(false)
+	})
+
+	MainWindow.SetContent(
+		container.NewCenter(
+			container.NewVBox(
+				widget.NewLabelWithStyle("⚡ Recherche d'adversaires...", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+				widget.NewProgressBarInfinite(),
+				widget.NewLabel(fmt.Sprintf("⏱️ Attente : %ds", qs.Waiting)),
+				widget.NewLabel(fmt.Sprintf("👥 Joueurs en attente : %d", qs.Queued)),
+				widget.NewLabel(fmt.Sprintf("📊 Ton niveau : %d (± %d)", qs.Rating, qs.RatingGap)),
+				cancelBtn,
+			),
+		),
+	)
+}
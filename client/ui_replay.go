@@ -0,0 +1,321 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"quiz-app-fyne/shared"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Délai entre deux questions en lecture automatique
+const replayStepDelay = 3 * time.Second
+
+// Taille du graphique d'évolution des scores
+var replayChartSize = fyne.NewSize(420, 160)
+
+// Couleurs des courbes, une par joueur
+var replayColors = []color.Color{
+	color.NRGBA{R: 0x1e, G: 0x88, B: 0xe5, A: 0xff},
+	color.NRGBA{R: 0xe5, G: 0x39, B: 0x35, A: 0xff},
+	color.NRGBA{R: 0x43, G: 0xa0, B: 0x47, A: 0xff},
+	color.NRGBA{R: 0xfb, G: 0x8c, B: 0x00, A: 0xff},
+	color.NRGBA{R: 0x8e, G: 0x24, B: 0xaa, A: 0xff},
+	color.NRGBA{R: 0x00, G: 0xac, B: 0xc1, A: 0xff},
+	color.NRGBA{R: 0x6d, G: 0x4c, B: 0x41, A: 0xff},
+	color.NRGBA{R: 0x54, G: 0x6e, B: 0x7a, A: 0xff},
+}
+
+// ShowGameHistory affiche les dernières parties du joueur
+func ShowGameHistory(games []shared.GameSummary) {
+	list := container.NewVBox()
+	if len(games) == 0 {
+		list.Add(widget.NewLabel("Aucune partie enregistrée pour le moment"))
+	}
+	for _, g := range games {
+		game := g
+		title := fmt.Sprintf("%s — partie %s", game.StartedAt.Local().Format("02/01 15:04"), game.Code)
+		result := fmt.Sprintf("%d pts", game.Score)
+		if game.Placement > 0 {
+			result = fmt.Sprintf("%de/%d · %s", game.Placement, game.Players, result)
+		}
+		if game.RatingDelta != 0 {
+			result += fmt.Sprintf(" · ⭐ %+d", game.RatingDelta)
+		}
+		switch {
+		case game.Forfeit:
+			result += " · abandon"
+		case game.FinishedAt == nil:
+			result += " · inachevée"
+		case game.StopReason != "":
+			result += " · " + game.StopReason
+		}
+
+		replayBtn := widget.NewButtonWithIcon("Revoir", theme.MediaPlayIcon(), func() {
+			SendReplayRequest(game.GameID)
+		})
+		list.Add(widget.NewCard(title, result, replayBtn))
+	}
+
+	backBtn := widget.NewButtonWithIcon("Retour", theme.NavigateBackIcon(), func() {
+		ShowModeSelectionScreen()
+	})
+
+	MainWindow.SetContent(
+		container.NewBorder(
+			widget.NewLabelWithStyle("📜 Mes parties", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+			backBtn,
+			nil, nil,
+			container.NewVScroll(list),
+		),
+	)
+}
+
+// Parties du replay en cours de réception, par numéro de partie
+var replayParts map[int]shared.ReplayPayload
+var replayGameID string
+
+// ReceiveReplayPart rassemble les parties d'un replay et l'affiche une fois complet
+func ReceiveReplayPart(rp shared.ReplayPayload) {
+	if rp.Parts <= 1 {
+		replayParts = nil
+		ShowReplay(rp)
+		return
+	}
+	if replayParts == nil || replayGameID != rp.Game.GameID {
+		replayParts = make(map[int]shared.ReplayPayload, rp.Parts)
+		replayGameID = rp.Game.GameID
+	}
+	replayParts[rp.Part] = rp
+	if len(replayParts) < rp.Parts {
+		return
+	}
+
+	replay := replayParts[1]
+	replay.Steps = nil
+	for part := 1; part <= rp.Parts; part++ {
+		replay.Steps = append(replay.Steps, replayParts[part].Steps...)
+	}
+	replayParts = nil
+	ShowReplay(replay)
+}
+
+// ShowReplay rejoue une partie question par question, en lecture automatique ou pas à pas
+func ShowReplay(replay shared.ReplayPayload) {
+	if len(replay.Steps) == 0 {
+		dialog.ShowInformation("Replay", "Aucune question enregistrée pour cette partie", MainWindow)
+		return
+	}
+
+	names := make(map[int]string, len(replay.Players))
+	for _, p := range replay.Players {
+		names[p.UserID] = p.Username
+	}
+
+	step := 0
+	var stop chan struct{}
+
+	stepLabel := widget.NewLabelWithStyle("", fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+	questionLabel := widget.NewLabel("")
+	questionLabel.Wrapping = fyne.TextWrapWord
+	options := container.NewVBox()
+	answers := container.NewVBox()
+	chart := container.NewStack()
+
+	render := func() {
+		s := replay.Steps[step]
+		q := s.Question
+		stepLabel.SetText(fmt.Sprintf("Question %d/%d — %s", step+1, len(replay.Steps), q.RoundName))
+		questionLabel.SetText(q.Text)
+
+		options.RemoveAll()
+		if len(q.Options) > 0 {
+			for i, option := range q.Options {
+				letter := string(rune('A' + i))
+				line := fmt.Sprintf("%s. %s", letter, option)
+				if letter == q.CorrectAnswer {
+					line += " ✔️"
+				}
+				options.Add(widget.NewLabel(line))
+			}
+		} else {
+			options.Add(widget.NewLabel("Réponse : " + q.CorrectAnswer))
+		}
+
+		answers.RemoveAll()
+		if len(s.Answers) == 0 {
+			answers.Add(widget.NewLabel("Aucune réponse"))
+		}
+		for _, a := range s.Answers {
+			answers.Add(widget.NewLabel(replayAnswerLine(names[a.UserID], a)))
+		}
+
+		chart.Objects = []fyne.CanvasObject{replayScoreChart(replay, step)}
+		chart.Refresh()
+	}
+
+	stopPlaying := func() {
+		if stop != nil {
+			close(stop)
+			stop = nil
+		}
+	}
+
+	var playBtn *widget.Button
+	playBtn = widget.NewButtonWithIcon("Lecture", theme.MediaPlayIcon(), func() {
+		if stop != nil {
+			stopPlaying()
+			playBtn.SetText("Lecture")
+			playBtn.SetIcon(theme.MediaPlayIcon())
+			return
+		}
+		if step == len(replay.Steps)-1 {
+			step = 0
+			render()
+		}
+		playBtn.SetText("Pause")
+		playBtn.SetIcon(theme.MediaPauseIcon())
+
+		stop = make(chan struct{})
+		go func(stop chan struct{}) {
+			ticker := time.NewTicker(replayStepDelay)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-stop:
+					return
+				case <-ticker.C:
+					fyne.Do(func() {
+						if step < len(replay.Steps)-1 {
+							step++
+							render()
+						}
+						if step == len(replay.Steps)-1 {
+							playBtn.OnTapped()
+						}
+					})
+				}
+			}
+		}(stop)
+	})
+
+	prevBtn := widget.NewButtonWithIcon("", theme.MediaSkipPreviousIcon(), func() {
+		if step > 0 {
+			step--
+			render()
+		}
+	})
+	nextBtn := widget.NewButtonWithIcon("", theme.MediaSkipNextIcon(), func() {
+		if step < len(replay.Steps)-1 {
+			step++
+			render()
+		}
+	})
+	backBtn := widget.NewButtonWithIcon("Retour", theme.NavigateBackIcon(), func() {
+		stopPlaying()
+		SendGameHistoryRequest()
+	})
+
+	render()
+	MainWindow.SetContent(
+		container.NewVScroll(
+			container.NewVBox(
+				widget.NewLabelWithStyle(fmt.Sprintf("🎬 Replay de la partie %s", replay.Game.Code), fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+				stepLabel,
+				questionLabel,
+				options,
+				widget.NewCard("", "Réponses", answers),
+				widget.NewCard("", "📈 Scores", container.NewVBox(chart, replayLegend(replay))),
+				container.NewHBox(prevBtn, playBtn, nextBtn),
+				backBtn,
+			),
+		),
+	)
+}
+
+// replayAnswerLine décrit l'action d'un joueur : réponse, proposition ou indice
+func replayAnswerLine(name string, a shared.TranscriptAnswer) string {
+	seconds := float64(a.ResponseMs) / 1000
+	if a.Kind == shared.ActionHint {
+		return fmt.Sprintf("%s : 💡 indice %s (%d) à %.1fs", name, a.Answer, a.Points, seconds)
+	}
+	if a.Kind == shared.ActionPowerUp {
+		return fmt.Sprintf("%s : 🃏 joker %s à %.1fs", name, powerUpLabels[a.Answer], seconds)
+	}
+	verdict := "❌"
+	if a.Correct {
+		verdict = "✅"
+	}
+	line := fmt.Sprintf("%s : %s %s en %.1fs", name, a.Answer, verdict, seconds)
+	if a.Points != 0 {
+		line += fmt.Sprintf(" (%+d)", a.Points)
+	}
+	return line
+}
+
+// replayLegend associe chaque joueur à la couleur de sa courbe
+func replayLegend(replay shared.ReplayPayload) fyne.CanvasObject {
+	legend := container.NewHBox()
+	for i, p := range replay.Players {
+		text := canvas.NewText(fmt.Sprintf("● %s (%d)", p.Username, p.Score), replayColors[i%len(replayColors)])
+		legend.Add(text)
+	}
+	return legend
+}
+
+// replayScoreChart trace l'évolution des scores de chaque joueur jusqu'à l'étape upTo
+func replayScoreChart(replay shared.ReplayPayload, upTo int) fyne.CanvasObject {
+	minScore, maxScore := 0, 1
+	for _, s := range replay.Steps {
+		for _, score := range s.Scores {
+			if score < minScore {
+				minScore = score
+			}
+			if score > maxScore {
+				maxScore = score
+			}
+		}
+	}
+
+	w, h := replayChartSize.Width, replayChartSize.Height
+	point := func(step, score int) fyne.Position {
+		x := float32(0)
+		if len(replay.Steps) > 1 {
+			x = w * float32(step) / float32(len(replay.Steps)-1)
+		}
+		y := h - h*float32(score-minScore)/float32(maxScore-minScore)
+		return fyne.NewPos(x, y)
+	}
+
+	zero := canvas.NewLine(theme.Color(theme.ColorNameDisabled))
+	zero.Position1 = point(0, 0)
+	zero.Position2 = fyne.NewPos(w, zero.Position1.Y)
+	objects := []fyne.CanvasObject{zero}
+
+	for i, p := range replay.Players {
+		prev := point(0, 0)
+		for step := 0; step <= upTo; step++ {
+			next := point(step, replay.Steps[step].Scores[p.UserID])
+			if step > 0 {
+				line := canvas.NewLine(replayColors[i%len(replayColors)])
+				line.StrokeWidth = 2
+				line.Position1 = prev
+				line.Position2 = next
+				objects = append(objects, line)
+			}
+			prev = next
+		}
+		dot := canvas.NewCircle(replayColors[i%len(replayColors)])
+		dot.Resize(fyne.NewSize(6, 6))
+		dot.Move(prev.Subtract(fyne.NewPos(3, 3)))
+		objects = append(objects, dot)
+	}
+
+	return container.NewGridWrap(replayChartSize, container.NewWithoutLayout(objects...))
+}
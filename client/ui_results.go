@@ -2,14 +2,24 @@ package main
 
 import (
 	"fmt"
+	"quiz-app-fyne/shared"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
 )
 
-func ShowResults(results []string) {
+// ShowResults affiche le classement final ; teams est vide hors mode équipes
+func ShowResults(teams []string, results []string) {
 	list := container.NewVBox()
+	for i, t := range teams {
+		list.Add(widget.NewLabelWithStyle(fmt.Sprintf("%d️⃣ %s", i+1, t), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	}
+	if len(teams) > 0 {
+		list.Add(widget.NewSeparator())
+	}
 	for i, r := range results {
 		list.Add(widget.NewLabel(fmt.Sprintf("%d️⃣ %s", i+1, r)))
 	}
@@ -23,3 +33,40 @@ func ShowResults(results []string) {
 		),
 	)
 }
+
+// ShowRoundReveal affiche le classement entre deux manches
+func ShowRoundReveal(sp shared.GameStatePayload) {
+	title := fmt.Sprintf("✅ Fin de la manche %d/%d : %s", sp.Round, sp.RoundCount, sp.RoundName)
+
+	MainWindow.SetContent(
+		container.NewVBox(
+			widget.NewLabelWithStyle(title, fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+			LiveScoreboard(),
+		),
+	)
+}
+
+// ShowElimination annonce les joueurs éliminés à la fin d'une manche
+func ShowElimination(ep shared.EliminationPayload) {
+	var text string
+	for _, id := range ep.Eliminated {
+		if CurrentUser != nil && id == CurrentUser.ID {
+			Spectating = true
+			text = "💀 Tu es éliminé ! Tu peux suivre la suite de la partie en spectateur.\n"
+		}
+	}
+	if len(ep.Names) > 0 {
+		text += fmt.Sprintf("Éliminé(s) à la manche %d : %s\n", ep.Round, strings.Join(ep.Names, ", "))
+	}
+	switch {
+	case ep.WinnerID != 0 && CurrentUser != nil && ep.WinnerID == CurrentUser.ID:
+		text += "🏆 Tu remportes le duel final !"
+	case ep.WinnerID != 0:
+		text += "🏆 Le duel final a désigné le vainqueur"
+	case ep.Remaining == 2:
+		text += "⚔️ Duel final !"
+	default:
+		text += fmt.Sprintf("%d joueurs encore en lice", ep.Remaining)
+	}
+	dialog.ShowInformation("Élimination", text, MainWindow)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"quiz-app-fyne/shared"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Liste des salles de l'écran de navigation, remplie à chaque réponse LIST_ROOMS
+var roomList *fyne.Container
+
+// ShowRoomBrowser affiche les salles publiques ouvertes et demande la liste au serveur
+func ShowRoomBrowser() {
+	roomList = container.NewVBox(widget.NewLabel("Chargement des salles..."))
+
+	refreshBtn := widget.NewButtonWithIcon("Actualiser", theme.ViewRefreshIcon(), func() {
+		SendListRooms()
+	})
+	backBtn := widget.NewButtonWithIcon("Retour", theme.NavigateBackIcon(), func() {
+		roomList = nil
+		ShowLobbyScreen()
+	})
+
+	MainWindow.SetContent(
+		container.NewBorder(
+			widget.NewLabelWithStyle("🔎 Salles publiques", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+			container.NewVBox(refreshBtn, backBtn),
+			nil, nil,
+			container.NewVScroll(roomList),
+		),
+	)
+	SendListRooms()
+}
+
+// ShowRoomList remplace la liste affichée par les salles reçues
+func ShowRoomList(rooms []shared.RoomInfo) {
+	if roomList == nil {
+		return
+	}
+	roomList.RemoveAll()
+	if len(rooms) == 0 {
+		roomList.Add(widget.NewLabel("Aucune salle ouverte pour le moment"))
+		return
+	}
+
+	for _, r := range rooms {
+		room := r
+		title := fmt.Sprintf("%s — salle de %s", room.GameCode, room.HostName)
+		if room.Private {
+			title += " 🔑"
+		}
+		status := fmt.Sprintf("👥 %d/%d joueurs", room.Players, room.MaxPlayers)
+		if room.Countdown > 0 {
+			status += fmt.Sprintf(" · ⏳ lancement dans %ds", room.Countdown)
+		}
+
+		joinBtn := widget.NewButtonWithIcon("Rejoindre 🎯", theme.MailSendIcon(), func() {
+			joinRoom(room)
+		})
+		roomList.Add(widget.NewCard(title, status, container.NewVBox(
+			widget.NewLabel(settingsSummary(room.Settings)),
+			joinBtn,
+		)))
+	}
+}
+
+// joinRoom rejoint une salle de la liste, en demandant le mot de passe si besoin
+func joinRoom(room shared.RoomInfo) {
+	if !room.Private {
+		SendJoinGame(room.GameCode, "", CurrentUser.ID)
+		return
+	}
+
+	password := widget.NewPasswordEntry()
+	dialog.ShowForm("Salle privée", "Rejoindre", "Annuler",
+		[]*widget.FormItem{widget.NewFormItem("Mot de passe", password)},
+		func(ok bool) {
+			if ok {
+				SendJoinGame(room.GameCode, password.Text, CurrentUser.ID)
+			}
+		}, MainWindow)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"quiz-app-fyne/shared"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Classement en direct affiché pendant la partie
+var scoreboardBox = container.NewVBox()
+
+// LiveScoreboard renvoie le classement compact à intégrer dans les écrans de jeu
+func LiveScoreboard() fyne.CanvasObject {
+	return widget.NewCard("", "📊 Classement", scoreboardBox)
+}
+
+// UpdateScoreboard rafraîchit le classement à la réception d'un SCORE_UPDATE.
+// En mode équipes, le classement des équipes précède les contributions individuelles.
+func UpdateScoreboard(scores []shared.ScoreEntry, teams []shared.TeamScore) {
+	scoreboardBox.RemoveAll()
+	for _, t := range teams {
+		line := fmt.Sprintf("%d. %s : %d", t.Rank, teamLabel(t.Team), t.Score)
+		scoreboardBox.Add(widget.NewLabelWithStyle(line, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	}
+	if len(teams) > 0 {
+		scoreboardBox.Add(widget.NewSeparator())
+	}
+	for _, s := range scores {
+		line := fmt.Sprintf("%d. %s : %d", s.Rank, s.Username, s.Score)
+		if s.Team > 0 {
+			line += " · " + shared.TeamName(s.Team)
+		}
+		if s.Delta > 0 {
+			line += fmt.Sprintf(" (+%d)", s.Delta)
+		} else if s.Delta < 0 {
+			line += fmt.Sprintf(" (%d)", s.Delta)
+		}
+		if s.Streak >= 2 {
+			line += fmt.Sprintf(" 🔥%d", s.Streak)
+		}
+		if s.Multiplier > 1 {
+			line += fmt.Sprintf(" ×%g", s.Multiplier)
+		}
+		if s.Eliminated {
+			line += " 💀"
+		}
+
+		style := fyne.TextStyle{}
+		if CurrentUser != nil && s.UserID == CurrentUser.ID {
+			style.Bold = true
+			// Aussi utile après une reconnexion : le classement dit si le joueur est éliminé
+			Spectating = s.Eliminated
+		}
+		scoreboardBox.Add(widget.NewLabelWithStyle(line, fyne.TextAlignLeading, style))
+	}
+	scoreboardBox.Refresh()
+}
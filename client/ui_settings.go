@@ -0,0 +1,334 @@
+package main
+
+import (
+	"fmt"
+	"quiz-app-fyne/shared"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Libellés des types de manches, dans l'ordre où elles sont jouées
+var roundLabels = []struct {
+	Kind  string
+	Label string
+}{
+	{shared.RoundQCM, "QCM"},
+	{shared.RoundTimeAttack, "Contre-la-montre"},
+	{shared.RoundRiddle, "Devinette"},
+	{shared.RoundBuzzer, "Buzzer"},
+	{shared.RoundWager, "Mise finale"},
+}
+
+// Répartitions de difficulté proposées (niveaux 1, 2, 3)
+var difficultyPresets = []struct {
+	Label string
+	Mix   []int
+}{
+	{"Facile", []int{100, 0, 0}},
+	{"Normal", []int{50, 50, 0}},
+	{"Difficile", []int{0, 50, 50}},
+	{"Mixte", []int{34, 33, 33}},
+}
+
+// Paliers de combo proposés
+var comboPresets = []struct {
+	Label string
+	Tiers []shared.StreakMultiplier
+}{
+	{"Aucun", []shared.StreakMultiplier{}},
+	{"×1.5 dès 3", []shared.StreakMultiplier{{Streak: 3, Multiplier: 1.5}}},
+	{"×1.5 dès 3, ×2 dès 5", []shared.StreakMultiplier{{Streak: 3, Multiplier: 1.5}, {Streak: 5, Multiplier: 2}}},
+	{"×2 dès 3, ×3 dès 6", []shared.StreakMultiplier{{Streak: 3, Multiplier: 2}, {Streak: 6, Multiplier: 3}}},
+}
+
+// Calculs du score d'équipe proposés
+var teamScoringLabels = []struct {
+	Rule  string
+	Label string
+}{
+	{shared.TeamScoreSum, "Somme"},
+	{shared.TeamScoreAverage, "Moyenne"},
+	{shared.TeamScoreBest, "Meilleur joueur"},
+}
+
+// Départages proposés pour les égalités en fin de partie
+var tieBreakLabels = []struct {
+	Rule  string
+	Label string
+}{
+	{shared.TieBreakNone, "Aucun"},
+	{shared.TieBreakFirst, "1re place"},
+	{shared.TieBreakPodium, "Podium"},
+}
+
+func teamScoringLabel(rule string) string {
+	for _, t := range teamScoringLabels {
+		if t.Rule == rule {
+			return t.Label
+		}
+	}
+	return rule
+}
+
+func roundLabel(kind string) string {
+	for _, r := range roundLabels {
+		if r.Kind == kind {
+			return r.Label
+		}
+	}
+	return kind
+}
+
+// settingsSummary décrit les paramètres de la partie pour le lobby
+func settingsSummary(s shared.GameSettings) string {
+	var rounds []string
+	for _, kind := range s.Rounds {
+		rounds = append(rounds, roundLabel(kind))
+	}
+	categories := "toutes"
+	if len(s.Categories) > 0 {
+		categories = strings.Join(s.Categories, ", ")
+	}
+
+	lines := []string{
+		fmt.Sprintf("Joueurs max : %d", s.MaxPlayers),
+	}
+	if s.Teams > 0 {
+		lines = append(lines, fmt.Sprintf("Équipes : %d (score : %s)", s.Teams, strings.ToLower(teamScoringLabel(s.TeamScoring))))
+	}
+	if s.Elimination {
+		lines = append(lines, fmt.Sprintf("Élimination : %d joueur(s) par manche jusqu'au duel final", s.EliminatePerBlock))
+	}
+	lines = append(lines,
+		"Manches : "+strings.Join(rounds, " → "),
+		fmt.Sprintf("Questions : %d (niv.1 %d%% / niv.2 %d%% / niv.3 %d%%)",
+			s.QuestionsPerRound, s.DifficultyMix[0], s.DifficultyMix[1], s.DifficultyMix[2]),
+		"Catégories : "+categories,
+		fmt.Sprintf("Temps par question : %ds", s.TimePerQuestion),
+	)
+	if len(s.HintCosts) == 2 {
+		hints := fmt.Sprintf("Indices : -%d / -%d pts", s.HintCosts[0], s.HintCosts[1])
+		if s.HintBudget > 0 {
+			hints += fmt.Sprintf(" (budget %d pts)", s.HintBudget)
+		}
+		lines = append(lines, hints)
+	}
+	lines = append(lines, fmt.Sprintf("Devinette : %d essai(s), %d faute(s) tolérée(s)", s.RiddleAttempts, s.TypoTolerance))
+	if len(s.StreakMultipliers) > 0 {
+		var tiers []string
+		for _, t := range s.StreakMultipliers {
+			tiers = append(tiers, fmt.Sprintf("×%g après %d", t.Multiplier, t.Streak))
+		}
+		lines = append(lines, "Combos : "+strings.Join(tiers, ", ")+" bonnes réponses d'affilée")
+	}
+	switch s.TieBreak {
+	case shared.TieBreakFirst:
+		lines = append(lines, "Départage : mort subite en cas d'égalité pour la 1re place")
+	case shared.TieBreakPodium:
+		lines = append(lines, "Départage : mort subite en cas d'égalité sur le podium")
+	}
+	if s.PowerUps > 0 {
+		lines = append(lines, fmt.Sprintf("Jokers QCM : %d de chaque (50/50, x2, passer, temps bonus)", s.PowerUps))
+	}
+
+	forfeit := "Abandon : score perdu"
+	if s.ForfeitKeepScore {
+		forfeit = "Abandon : score conservé"
+	}
+	if s.ForfeitCountsGame {
+		forfeit += ", partie comptée"
+	}
+	lines = append(lines, forfeit)
+	return strings.Join(lines, "\n")
+}
+
+// ShowGameSettingsScreen permet à l'hôte de choisir les paramètres avant de créer la partie
+func ShowGameSettingsScreen() {
+	defaults := shared.DefaultGameSettings()
+
+	var labels []string
+	var selected []string
+	for _, r := range roundLabels {
+		labels = append(labels, r.Label)
+		for _, kind := range defaults.Rounds {
+			if kind == r.Kind {
+				selected = append(selected, r.Label)
+			}
+		}
+	}
+	maxPlayers := widget.NewSelect([]string{"2", "4", "6", "8", "12", "16"}, nil)
+	maxPlayers.SetSelected(strconv.Itoa(defaults.MaxPlayers))
+
+	password := widget.NewPasswordEntry()
+	password.SetPlaceHolder("Aucun")
+	public := widget.NewCheck("Visible dans la liste des salles", nil)
+
+	teams := widget.NewSelect([]string{"Aucune", "2", "3", "4"}, nil)
+	teams.SetSelected("Aucune")
+	var scoringLabels []string
+	for _, t := range teamScoringLabels {
+		scoringLabels = append(scoringLabels, t.Label)
+	}
+	teamScoring := widget.NewSelect(scoringLabels, nil)
+	teamScoring.SetSelected(teamScoringLabel(shared.TeamScoreSum))
+
+	elimination := widget.NewCheck("Les derniers sont éliminés à chaque manche", nil)
+	eliminatePerBlock := widget.NewSelect([]string{"1", "2", "3"}, nil)
+	eliminatePerBlock.SetSelected("1")
+
+	rounds := widget.NewCheckGroup(labels, nil)
+	rounds.SetSelected(selected)
+
+	questions := widget.NewSelect([]string{"4", "6", "8", "10", "12", "15", "20"}, nil)
+	questions.SetSelected(strconv.Itoa(defaults.QuestionsPerRound))
+
+	var presetLabels []string
+	for _, p := range difficultyPresets {
+		presetLabels = append(presetLabels, p.Label)
+	}
+	difficulty := widget.NewSelect(presetLabels, nil)
+	difficulty.SetSelected("Normal")
+
+	categories := widget.NewEntry()
+	categories.SetPlaceHolder("Toutes (ex : Histoire, Sport)")
+
+	timePerQuestion := widget.NewSelect([]string{"5", "10", "15", "20", "30"}, nil)
+	timePerQuestion.SetSelected(strconv.Itoa(defaults.TimePerQuestion))
+
+	hint1 := widget.NewEntry()
+	hint1.SetText(strconv.Itoa(defaults.HintCosts[0]))
+	hint2 := widget.NewEntry()
+	hint2.SetText(strconv.Itoa(defaults.HintCosts[1]))
+
+	budget := widget.NewSelect([]string{"0", "50", "75", "100", "150", "200"}, nil)
+	budget.SetSelected(strconv.Itoa(defaults.HintBudget))
+
+	tolerance := widget.NewSelect([]string{"0", "1", "2", "3"}, nil)
+	tolerance.SetSelected(strconv.Itoa(defaults.TypoTolerance))
+
+	forfeitKeep := widget.NewCheck("Score conservé", nil)
+	forfeitKeep.SetChecked(defaults.ForfeitKeepScore)
+	forfeitCount := widget.NewCheck("Partie comptée comme jouée", nil)
+	forfeitCount.SetChecked(defaults.ForfeitCountsGame)
+
+	attempts := widget.NewSelect([]string{"1", "2", "3", "5", "10"}, nil)
+	attempts.SetSelected(strconv.Itoa(defaults.RiddleAttempts))
+
+	powerUps := widget.NewSelect([]string{"0", "1", "2", "3"}, nil)
+	powerUps.SetSelected(strconv.Itoa(defaults.PowerUps))
+
+	var comboLabels []string
+	for _, c := range comboPresets {
+		comboLabels = append(comboLabels, c.Label)
+	}
+	combos := widget.NewSelect(comboLabels, nil)
+	combos.SetSelected("×1.5 dès 3, ×2 dès 5")
+
+	var tieBreakNames []string
+	for _, t := range tieBreakLabels {
+		tieBreakNames = append(tieBreakNames, t.Label)
+	}
+	tieBreak := widget.NewSelect(tieBreakNames, nil)
+	tieBreak.SetSelected("1re place")
+
+	form := widget.NewForm(
+		widget.NewFormItem("Joueurs max", maxPlayers),
+		widget.NewFormItem("Mot de passe", password),
+		widget.NewFormItem("Salle publique", public),
+		widget.NewFormItem("Équipes", teams),
+		widget.NewFormItem("Score d'équipe", teamScoring),
+		widget.NewFormItem("Élimination", elimination),
+		widget.NewFormItem("Éliminés par manche", eliminatePerBlock),
+		widget.NewFormItem("Manches", rounds),
+		widget.NewFormItem("Questions", questions),
+		widget.NewFormItem("Difficulté", difficulty),
+		widget.NewFormItem("Catégories", categories),
+		widget.NewFormItem("Secondes / question", timePerQuestion),
+		widget.NewFormItem("Indice 1 (pts)", hint1),
+		widget.NewFormItem("Indice 2 (pts)", hint2),
+		widget.NewFormItem("Budget indices (0 = illimité)", budget),
+		widget.NewFormItem("Fautes tolérées", tolerance),
+		widget.NewFormItem("Essais devinette", attempts),
+		widget.NewFormItem("Jokers QCM (de chaque)", powerUps),
+		widget.NewFormItem("Combos", combos),
+		widget.NewFormItem("Départage des égalités", tieBreak),
+		widget.NewFormItem("En cas d'abandon", container.NewVBox(forfeitKeep, forfeitCount)),
+	)
+
+	createBtn := widget.NewButtonWithIcon("Créer la partie ➕", theme.ContentAddIcon(), func() {
+		settings := defaults
+		settings.MaxPlayers, _ = strconv.Atoi(maxPlayers.Selected)
+		settings.Teams, _ = strconv.Atoi(teams.Selected) // "Aucune" → 0
+		if settings.Teams > 0 {
+			for _, t := range teamScoringLabels {
+				if t.Label == teamScoring.Selected {
+					settings.TeamScoring = t.Rule
+				}
+			}
+		}
+		settings.Rounds = nil
+		for _, r := range roundLabels {
+			for _, label := range rounds.Selected {
+				if label == r.Label {
+					settings.Rounds = append(settings.Rounds, r.Kind)
+				}
+			}
+		}
+		settings.QuestionsPerRound, _ = strconv.Atoi(questions.Selected)
+		for _, p := range difficultyPresets {
+			if p.Label == difficulty.Selected {
+				settings.DifficultyMix = p.Mix
+			}
+		}
+		settings.Categories = []string{}
+		for _, c := range strings.Split(categories.Text, ",") {
+			if c = strings.TrimSpace(c); c != "" {
+				settings.Categories = append(settings.Categories, c)
+			}
+		}
+		settings.TimePerQuestion, _ = strconv.Atoi(timePerQuestion.Selected)
+		cost1, _ := strconv.Atoi(hint1.Text)
+		cost2, _ := strconv.Atoi(hint2.Text)
+		settings.HintCosts = []int{cost1, cost2}
+		settings.HintBudget, _ = strconv.Atoi(budget.Selected)
+		settings.TypoTolerance, _ = strconv.Atoi(tolerance.Selected)
+		settings.RiddleAttempts, _ = strconv.Atoi(attempts.Selected)
+		settings.PowerUps, _ = strconv.Atoi(powerUps.Selected)
+		for _, c := range comboPresets {
+			if c.Label == combos.Selected {
+				settings.StreakMultipliers = c.Tiers
+			}
+		}
+		for _, t := range tieBreakLabels {
+			if t.Label == tieBreak.Selected {
+				settings.TieBreak = t.Rule
+			}
+		}
+		settings.ForfeitKeepScore = forfeitKeep.Checked
+		settings.ForfeitCountsGame = forfeitCount.Checked
+		settings.Elimination = elimination.Checked
+		if settings.Elimination {
+			settings.EliminatePerBlock, _ = strconv.Atoi(eliminatePerBlock.Selected)
+		}
+
+		SendCreateGame(CurrentUser.ID, "multi", &settings, password.Text, public.Checked)
+	})
+
+	backBtn := widget.NewButtonWithIcon("Retour", theme.NavigateBackIcon(), func() {
+		ShowLobbyScreen()
+	})
+
+	MainWindow.SetContent(
+		container.NewVBox(
+			widget.NewLabelWithStyle("⚙️ Paramètres de la partie", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+			form,
+			createBtn,
+			backBtn,
+		),
+	)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"quiz-app-fyne/shared"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Vrai pendant une mort subite à laquelle le joueur ne participe pas (ou plus) :
+// il voit les questions sans pouvoir répondre
+var suddenDeathWatching bool
+
+// placeLabel écrit une place du classement (1re, 2e...)
+func placeLabel(place int) string {
+	if place == 1 {
+		return "1re"
+	}
+	return fmt.Sprintf("%de", place)
+}
+
+// suddenDeathNames renvoie les pseudos des joueurs listés dans ids
+func suddenDeathNames(sp shared.SuddenDeathPayload, ids []int) []string {
+	var names []string
+	for _, id := range ids {
+		for i, player := range sp.Players {
+			if player == id && i < len(sp.Names) {
+				names = append(names, sp.Names[i])
+			}
+		}
+	}
+	return names
+}
+
+// ShowSuddenDeath annonce une mort subite entre joueurs à égalité, puis affiche
+// son avancement après chaque question
+func ShowSuddenDeath(sp shared.SuddenDeathPayload) {
+	contender := false
+	for _, id := range sp.Remaining {
+		if CurrentUser != nil && id == CurrentUser.ID {
+			contender = true
+		}
+	}
+	suddenDeathWatching = !contender
+
+	var status string
+	switch {
+	case sp.WinnerID != 0 && CurrentUser != nil && sp.WinnerID == CurrentUser.ID:
+		status = fmt.Sprintf("🏆 Tu remportes la %s place !", placeLabel(sp.Place))
+	case sp.WinnerID != 0:
+		status = fmt.Sprintf("🏆 %s remporte la %s place", strings.Join(suddenDeathNames(sp, sp.Remaining), ""), placeLabel(sp.Place))
+	default:
+		status = "Encore en course : " + strings.Join(suddenDeathNames(sp, sp.Remaining), ", ")
+	}
+
+	// Résultat d'une question : affiché sous la question, qui reste à l'écran
+	if sp.Answer != "" && questionFeedback != nil {
+		questionFeedback.SetText(fmt.Sprintf("⚡ Bonne réponse : %s. %s", sp.Answer, status))
+		return
+	}
+
+	role := "⚔️ Tu joues le départage : la première erreur face à une bonne réponse t'écarte"
+	if !contender {
+		role = "👀 Tu regardes le départage"
+	}
+	MainWindow.SetContent(
+		container.NewVBox(
+			widget.NewLabelWithStyle(fmt.Sprintf("⚡ Mort subite pour la %s place !", placeLabel(sp.Place)), fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+			widget.NewLabelWithStyle("Égalité entre "+strings.Join(sp.Names, ", "), fyne.TextAlignCenter, fyne.TextStyle{}),
+			widget.NewLabelWithStyle(role, fyne.TextAlignCenter, fyne.TextStyle{Italic: true}),
+			widget.NewLabelWithStyle(status, fyne.TextAlignCenter, fyne.TextStyle{}),
+			LiveScoreboard(),
+		),
+	)
+}
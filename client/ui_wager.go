@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"quiz-app-fyne/shared"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Vrai de l'invitation à miser jusqu'à la révélation : la réponse à la question
+// finale reste sans verdict jusqu'à la fin de la manche
+var wagerInProgress bool
+
+// Confirmation de la mise affichée sous le formulaire
+var wagerStatus *widget.Label
+
+// ShowWagerScreen permet au joueur de miser en secret avant la question finale
+func ShowWagerScreen(wp shared.WagerPromptPayload) {
+	wagerInProgress = true
+	amount := 0
+
+	valueLabel := widget.NewLabelWithStyle("Mise : 0 pts", fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+	slider := widget.NewSlider(0, float64(wp.MaxWager))
+	slider.OnChanged = func(v float64) {
+		amount = int(v)
+		valueLabel.SetText(fmt.Sprintf("Mise : %d pts", amount))
+	}
+
+	nothing := widget.NewButton("Rien", func() { slider.SetValue(0) })
+	half := widget.NewButton("La moitié", func() { slider.SetValue(float64(wp.MaxWager / 2)) })
+	all := widget.NewButton("Tout 🔥", func() { slider.SetValue(float64(wp.MaxWager)) })
+
+	submit := widget.NewButtonWithIcon("Miser 🎲", theme.ConfirmIcon(), func() {
+		SendPlaceWager(amount)
+	})
+	wagerStatus = widget.NewLabelWithStyle(
+		fmt.Sprintf("⏳ %d s pour miser (sans mise, tu mises 0)", wp.Seconds),
+		fyne.TextAlignCenter,
+		fyne.TextStyle{Italic: true},
+	)
+
+	rules := widget.NewLabel(fmt.Sprintf("Mise jusqu'à %d pts : gagnée si tu trouves, perdue sinon.", wp.MaxWager))
+	form := container.NewVBox(valueLabel, slider, container.NewGridWithColumns(3, nothing, half, all), submit)
+	// Sans points, rien à miser : le joueur attend la question
+	if wp.MaxWager <= 0 {
+		rules.SetText("Tu n'as aucun point à miser : réponds quand même à la question finale !")
+		form.Hide()
+		wagerStatus.SetText(fmt.Sprintf("⏳ Question dans %d s", wp.Seconds))
+	}
+
+	MainWindow.SetContent(
+		container.NewVBox(
+			widget.NewLabelWithStyle("🎲 Mise finale", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+			widget.NewLabel(fmt.Sprintf("Catégorie : %s (niveau %d)", wp.Category, wp.Level)),
+			rules,
+			form,
+			wagerStatus,
+			LiveScoreboard(),
+			LeaveButton(),
+		),
+	)
+}
+
+// ShowWagerPlaced confirme la mise enregistrée par le serveur
+func ShowWagerPlaced(wp shared.WagerPlacedPayload) {
+	if wagerStatus == nil {
+		return
+	}
+	wagerStatus.SetText(fmt.Sprintf("✅ Mise enregistrée : %d pts (modifiable jusqu'à la fin du temps)", wp.Amount))
+}
+
+// ShowWagerReveal dévoile les mises une à une, du plus petit au plus grand score
+func ShowWagerReveal(rp shared.WagerRevealPayload) {
+	wagerInProgress = false
+	list := container.NewVBox()
+
+	MainWindow.SetContent(
+		container.NewVBox(
+			widget.NewLabelWithStyle("🎲 Révélation des mises", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+			widget.NewLabelWithStyle(fmt.Sprintf("Bonne réponse : %s) %s", rp.Answer, rp.AnswerText), fyne.TextAlignCenter, fyne.TextStyle{}),
+			list,
+		),
+	)
+
+	step := time.Duration(rp.RevealStepMs) * time.Millisecond
+	reveals := rp.Reveals
+	go func() {
+		for _, rv := range reveals {
+			time.Sleep(step)
+			line := wagerRevealLine(rv)
+			style := fyne.TextStyle{}
+			if CurrentUser != nil && rv.UserID == CurrentUser.ID {
+				style.Bold = true
+			}
+			fyne.Do(func() {
+				list.Add(widget.NewLabelWithStyle(line, fyne.TextAlignLeading, style))
+			})
+		}
+	}()
+}
+
+// wagerRevealLine décrit la mise d'un joueur et son issue
+func wagerRevealLine(rv shared.WagerReveal) string {
+	verdict := "❌"
+	switch {
+	case rv.Correct:
+		verdict = "✅"
+	case rv.Answer == "":
+		verdict = "⌛ sans réponse"
+	}
+	return fmt.Sprintf("%s a misé %d pts… %s %+d → %d pts", rv.Username, rv.Wager, verdict, rv.Points, rv.Score)
+}
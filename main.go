@@ -26,6 +26,9 @@ func main() {
 	}
 	defer conn.Close()
 
+	// Reprise des parties sauvegardées avant l'arrêt du serveur
+	server.Manager.RestoreGames(conn)
+
 	fmt.Println("🚀 Serveur UDP lancé sur le port", ServerPort)
 	log.Println("🚀 Serveur UDP prêt et à l'écoute")
 
@@ -2,8 +2,11 @@ package server
 
 import (
 	"database/sql"
+	"fmt"
 	"log"
 	"quiz-app-fyne/shared"
+	"strings"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -45,15 +48,147 @@ func NewDatabase(usersPath, quizPath string) (*Database, error) {
 		quizDB.Close()
 		return nil, err
 	}
-	return &Database{usersDB: usersDB, quizDB: quizDB}, nil
+	db := &Database{usersDB: usersDB, quizDB: quizDB}
+	if err := db.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+func (db *Database) Close() {
+	db.usersDB.Close()
+	db.quizDB.Close()
+}
+
+// MIGRATIONS
+// migrate ajoute aux bases existantes les colonnes et tables manquantes
+func (db *Database) migrate() error {
+	// Réponses alternatives acceptées pour une devinette, séparées par "|"
+	if err := addColumnIfMissing(db.quizDB, "riddles", "accepted_answers", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+
+	// Classement Elo (InitialRating pour les comptes existants)
+	if err := addColumnIfMissing(db.usersDB, "users", "rating", fmt.Sprintf("INTEGER NOT NULL DEFAULT %d", InitialRating)); err != nil {
+		return err
+	}
+	for _, stmt := range usersSchema {
+		if _, err := db.usersDB.Exec(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Tables créées si absentes dans la base des utilisateurs
+var usersSchema = []string{
+	// Parties : créées au lancement, finished_at renseigné une seule fois (voir RecordGameEnd)
+	`CREATE TABLE IF NOT EXISTS games (
+		id TEXT PRIMARY KEY,
+		code TEXT NOT NULL,
+		mode TEXT NOT NULL,
+		stop_reason TEXT NOT NULL DEFAULT '',
+		players INTEGER NOT NULL,
+		rated BOOLEAN NOT NULL DEFAULT 0,
+		started_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		finished_at TIMESTAMP
+	)`,
+	// Évolution du classement, une ligne par joueur et par partie classée
+	`CREATE TABLE IF NOT EXISTS rating_history (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id INTEGER NOT NULL REFERENCES users(id),
+		game_id TEXT NOT NULL REFERENCES games(id),
+		old_rating INTEGER NOT NULL,
+		new_rating INTEGER NOT NULL,
+		placement INTEGER NOT NULL,
+		players INTEGER NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`,
+	// Participants et résultat final de chacun
+	`CREATE TABLE IF NOT EXISTS game_players (
+		game_id TEXT NOT NULL REFERENCES games(id),
+		user_id INTEGER NOT NULL REFERENCES users(id),
+		score INTEGER NOT NULL DEFAULT 0,
+		placement INTEGER NOT NULL DEFAULT 0,
+		old_rating INTEGER NOT NULL DEFAULT 0,
+		new_rating INTEGER NOT NULL DEFAULT 0,
+		forfeit BOOLEAN NOT NULL DEFAULT 0,
+		PRIMARY KEY (game_id, user_id)
+	)`,
+	// Questions et devinettes posées, manche par manche
+	`CREATE TABLE IF NOT EXISTS game_questions (
+		game_id TEXT NOT NULL REFERENCES games(id),
+		round INTEGER NOT NULL,
+		round_name TEXT NOT NULL,
+		question_id INTEGER NOT NULL,
+		text TEXT NOT NULL,
+		options TEXT NOT NULL DEFAULT '',
+		correct_answer TEXT NOT NULL,
+		asked_at TIMESTAMP NOT NULL,
+		PRIMARY KEY (game_id, round, question_id)
+	)`,
+	// Réponses, propositions et achats d'indices
+	`CREATE TABLE IF NOT EXISTS game_answers (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		game_id TEXT NOT NULL REFERENCES games(id),
+		round INTEGER NOT NULL,
+		question_id INTEGER NOT NULL,
+		user_id INTEGER NOT NULL REFERENCES users(id),
+		kind TEXT NOT NULL,
+		answer TEXT NOT NULL,
+		correct BOOLEAN NOT NULL,
+		response_ms INTEGER NOT NULL,
+		points INTEGER NOT NULL,
+		score INTEGER NOT NULL,
+		answered_at TIMESTAMP NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_game_answers_game ON game_answers(game_id)`,
+	// Sauvegardes des parties non terminées, rechargées au démarrage (voir snapshot.go)
+	`CREATE TABLE IF NOT EXISTS game_snapshots (
+		game_id TEXT PRIMARY KEY,
+		data TEXT NOT NULL,
+		saved_at TIMESTAMP NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_game_players_user ON game_players(user_id)`,
+}
+
+func addColumnIfMissing(conn *sql.DB, table, column, definition string) error {
+	rows, err := conn.Query(`PRAGMA table_info(` + table + `)`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, colType string
+		var defaultValue sql.NullString
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &defaultValue, &pk); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	_, err = conn.Exec(`ALTER TABLE ` + table + ` ADD COLUMN ` + column + ` ` + definition)
+	if err == nil {
+		log.Printf("🛠️ Colonne %s.%s ajoutée", table, column)
+	}
+	return err
 }
 
 // UTILISATEURS
 func (db *Database) GetUserByEmail(email string) (*shared.User, error) {
-	row := db.usersDB.QueryRow(`SELECT id, email, username, password_hash, total_score, games_played, created_at, last_login FROM users WHERE email=?`, email)
+	row := db.usersDB.QueryRow(`SELECT id, email, username, password_hash, total_score, games_played, rating, created_at, last_login FROM users WHERE email=?`, email)
 	user := &shared.User{}
 	var lastLogin sql.NullTime
-	err := row.Scan(&user.ID, &user.Email, &user.Username, &user.PasswordHash, &user.TotalScore, &user.GamesPlayed, &user.CreatedAt, &lastLogin)
+	err := row.Scan(&user.ID, &user.Email, &user.Username, &user.PasswordHash, &user.TotalScore, &user.GamesPlayed, &user.Rating, &user.CreatedAt, &lastLogin)
 	if err != nil {
 		return nil, err
 	}
@@ -64,10 +199,10 @@ func (db *Database) GetUserByEmail(email string) (*shared.User, error) {
 }
 
 func (db *Database) GetUserByID(id int) (*shared.User, error) {
-	row := db.usersDB.QueryRow(`SELECT id, email, username, password_hash, total_score, games_played, created_at, last_login FROM users WHERE id=?`, id)
+	row := db.usersDB.QueryRow(`SELECT id, email, username, password_hash, total_score, games_played, rating, created_at, last_login FROM users WHERE id=?`, id)
 	user := &shared.User{}
 	var lastLogin sql.NullTime
-	err := row.Scan(&user.ID, &user.Email, &user.Username, &user.PasswordHash, &user.TotalScore, &user.GamesPlayed, &user.CreatedAt, &lastLogin)
+	err := row.Scan(&user.ID, &user.Email, &user.Username, &user.PasswordHash, &user.TotalScore, &user.GamesPlayed, &user.Rating, &user.CreatedAt, &lastLogin)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +220,10 @@ func (db *Database) GetQuestionsByLevelAndManche(level, manche, limit int) ([]sh
 		return nil, err
 	}
 	defer rows.Close()
+	return scanQuestions(rows)
+}
 
+func scanQuestions(rows *sql.Rows) ([]shared.Question, error) {
 	var questions []shared.Question
 	for rows.Next() {
 		var q shared.Question
@@ -98,6 +236,48 @@ func (db *Database) GetQuestionsByLevelAndManche(level, manche, limit int) ([]sh
 	return questions, nil
 }
 
+// GetQuestions - Questions aléatoires filtrées par catégories (vide = toutes)
+func (db *Database) GetQuestions(level, manche, limit int, categories []string) ([]shared.Question, error) {
+	if len(categories) == 0 {
+		return db.GetQuestionsByLevelAndManche(level, manche, limit)
+	}
+
+	args := []interface{}{level, manche}
+	placeholders := make([]string, len(categories))
+	for i, c := range categories {
+		placeholders[i] = "?"
+		args = append(args, c)
+	}
+	args = append(args, limit)
+
+	rows, err := db.quizDB.Query(`SELECT id, question_text, choice_a, choice_b, choice_c, choice_d, correct_answer, difficulty_level, manche, category
+		FROM questions WHERE difficulty_level=? AND manche=? AND category IN (`+strings.Join(placeholders, ",")+`) ORDER BY RANDOM() LIMIT ?`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanQuestions(rows)
+}
+
+// GetCategories - Liste des catégories de questions
+func (db *Database) GetCategories() ([]string, error) {
+	rows, err := db.quizDB.Query(`SELECT DISTINCT category FROM questions ORDER BY category`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []string
+	for rows.Next() {
+		var c string
+		if err := rows.Scan(&c); err != nil {
+			return nil, err
+		}
+		categories = append(categories, c)
+	}
+	return categories, nil
+}
+
 // Manche 1 à 8 QCM (4 niveau1 + 4 niveau2)
 func (db *Database) GetRandomQuestionsForManche1() ([]shared.Question, error) {
 	q1, err := db.GetQuestionsByLevelAndManche(1, 1, 4)
@@ -118,21 +298,346 @@ func (db *Database) GetRandomQuestionsForManche2() ([]shared.Question, error) {
 
 // Manche 3 : devinette
 func (db *Database) GetRandomRiddle() (*shared.Riddle, error) {
-	row := db.quizDB.QueryRow(`SELECT id, riddle_text, correct_word, hint_level1, hint_level2, difficulty_level FROM riddles ORDER BY RANDOM() LIMIT 1`)
+	row := db.quizDB.QueryRow(`SELECT id, riddle_text, correct_word, hint_level1, hint_level2, difficulty_level, accepted_answers FROM riddles ORDER BY RANDOM() LIMIT 1`)
 	r := &shared.Riddle{}
-	err := row.Scan(&r.ID, &r.RiddleText, &r.CorrectWord, &r.HintLevel1, &r.HintLevel2, &r.DifficultyLevel)
+	var accepted string
+	err := row.Scan(&r.ID, &r.RiddleText, &r.CorrectWord, &r.HintLevel1, &r.HintLevel2, &r.DifficultyLevel, &accepted)
 	if err != nil {
 		return nil, err
 	}
+	r.AcceptedAnswers = []string{r.CorrectWord}
+	for _, a := range strings.Split(accepted, "|") {
+		if a = strings.TrimSpace(a); a != "" {
+			r.AcceptedAnswers = append(r.AcceptedAnswers, a)
+		}
+	}
 	return r, nil
 }
 
-// UpdateUserScore - Met à jour le score d'un utilisateur
-func (db *Database) UpdateUserScore(userID, score int) error {
+// RecordForfeit - Enregistre l'abandon d'un joueur selon les règles de la salle
+func (db *Database) RecordForfeit(userID, score int, countGame bool) error {
+	played := 0
+	if countGame {
+		played = 1
+	}
 	_, err := db.usersDB.Exec(
-		`UPDATE users SET total_score = total_score + ?, games_played = games_played + 1 WHERE id = ?`,
+		`UPDATE users SET total_score = total_score + ?, games_played = games_played + ? WHERE id = ?`,
 		score,
+		played,
 		userID,
 	)
 	return err
 }
+
+// GameRecord - Résultat final d'une partie à enregistrer
+type GameRecord struct {
+	ID         string      // Identifiant unique de la partie (clé d'idempotence)
+	Code       string      // Code de salle affiché aux joueurs
+	Mode       string      // "solo" ou "multi"
+	StopReason string      // Raison d'un arrêt anticipé
+	Scores     map[int]int // Score final de chaque joueur présent à la fin
+	Placements map[int]int // Place finale de chaque joueur
+	Rated      bool        // Mise à jour du classement Elo
+}
+
+// RecordGameEnd - Enregistre la fin d'une partie en une seule transaction : la partie,
+// le résultat de chaque joueur, ses compteurs et son classement. Un second appel pour
+// la même partie ne modifie rien et renvoie les classements déjà enregistrés.
+func (db *Database) RecordGameEnd(rec GameRecord) (map[int]RatingChange, error) {
+	tx, err := db.usersDB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// La partie est normalement créée au lancement par l'historique
+	if _, err := tx.Exec(
+		`INSERT OR IGNORE INTO games (id, code, mode, players, started_at) VALUES (?, ?, ?, ?, ?)`,
+		rec.ID, rec.Code, rec.Mode, len(rec.Scores), time.Now(),
+	); err != nil {
+		return nil, err
+	}
+	res, err := tx.Exec(
+		`UPDATE games SET stop_reason = ?, players = ?, rated = ?, finished_at = ? WHERE id = ? AND finished_at IS NULL`,
+		rec.StopReason, len(rec.Scores), rec.Rated, time.Now(), rec.ID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if updated, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if updated == 0 {
+		log.Printf("ℹ️ Partie %s déjà enregistrée", rec.ID)
+		return recordedRatings(tx, rec.ID)
+	}
+
+	ratings := make(map[int]int, len(rec.Scores))
+	for id := range rec.Scores {
+		var rating int
+		if err := tx.QueryRow(`SELECT rating FROM users WHERE id = ?`, id).Scan(&rating); err != nil {
+			return nil, err
+		}
+		ratings[id] = rating
+	}
+	deltas := map[int]int{}
+	if rec.Rated {
+		deltas = eloDeltas(ratings, rec.Placements)
+	}
+
+	changes := make(map[int]RatingChange, len(rec.Scores))
+	for id, score := range rec.Scores {
+		change := RatingChange{Old: ratings[id], New: ratings[id] + deltas[id]}
+		if _, err := tx.Exec(
+			`UPDATE users SET total_score = total_score + ?, games_played = games_played + 1, rating = ? WHERE id = ?`,
+			score, change.New, id,
+		); err != nil {
+			return nil, err
+		}
+		if _, err := tx.Exec(
+			`INSERT INTO game_players (game_id, user_id, score, placement, old_rating, new_rating) VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT (game_id, user_id) DO UPDATE SET score = excluded.score, placement = excluded.placement,
+			old_rating = excluded.old_rating, new_rating = excluded.new_rating`,
+			rec.ID, id, score, rec.Placements[id], change.Old, change.New,
+		); err != nil {
+			return nil, err
+		}
+		if !rec.Rated {
+			continue
+		}
+		if _, err := tx.Exec(
+			`INSERT INTO rating_history (user_id, game_id, old_rating, new_rating, placement, players) VALUES (?, ?, ?, ?, ?, ?)`,
+			id, rec.ID, change.Old, change.New, rec.Placements[id], len(rec.Scores),
+		); err != nil {
+			return nil, err
+		}
+		changes[id] = change
+	}
+	return changes, tx.Commit()
+}
+
+// recordedRatings relit les classements d'une partie déjà enregistrée
+func recordedRatings(tx *sql.Tx, gameID string) (map[int]RatingChange, error) {
+	changes := map[int]RatingChange{}
+	var rated bool
+	if err := tx.QueryRow(`SELECT rated FROM games WHERE id = ?`, gameID).Scan(&rated); err != nil || !rated {
+		return changes, err
+	}
+
+	rows, err := tx.Query(`SELECT user_id, old_rating, new_rating FROM game_players WHERE game_id = ?`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int
+		var change RatingChange
+		if err := rows.Scan(&id, &change.Old, &change.New); err != nil {
+			return nil, err
+		}
+		changes[id] = change
+	}
+	return changes, rows.Err()
+}
+
+// HISTORIQUE
+// RecordGameStart - Crée la partie et ses participants au lancement
+func (db *Database) RecordGameStart(gameID, code, mode string, players []int, startedAt time.Time) error {
+	tx, err := db.usersDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(
+		`INSERT OR IGNORE INTO games (id, code, mode, players, started_at) VALUES (?, ?, ?, ?, ?)`,
+		gameID, code, mode, len(players), startedAt,
+	); err != nil {
+		return err
+	}
+	for _, id := range players {
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO game_players (game_id, user_id) VALUES (?, ?)`, gameID, id); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// RecordQuestion - Enregistre une question posée (ignorée si déjà enregistrée pour la manche)
+func (db *Database) RecordQuestion(gameID string, q shared.TranscriptQuestion) error {
+	_, err := db.usersDB.Exec(
+		`INSERT OR IGNORE INTO game_questions (game_id, round, round_name, question_id, text, options, correct_answer, asked_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		gameID, q.Round, q.RoundName, q.QuestionID, q.Text, strings.Join(q.Options, "|"), q.CorrectAnswer, q.AskedAt,
+	)
+	return err
+}
+
+// RecordAnswer - Enregistre une réponse ou un achat d'indice
+func (db *Database) RecordAnswer(gameID string, a shared.TranscriptAnswer) error {
+	_, err := db.usersDB.Exec(
+		`INSERT INTO game_answers (game_id, round, question_id, user_id, kind, answer, correct, response_ms, points, score, answered_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		gameID, a.Round, a.QuestionID, a.UserID, a.Kind, a.Answer, a.Correct, a.ResponseMs, a.Points, a.Score, a.AnsweredAt,
+	)
+	return err
+}
+
+// RecordLeave - Marque l'abandon d'un participant avec le score retenu
+func (db *Database) RecordLeave(gameID string, userID, score int) error {
+	_, err := db.usersDB.Exec(
+		`UPDATE game_players SET forfeit = 1, score = ? WHERE game_id = ? AND user_id = ?`,
+		score, gameID, userID,
+	)
+	return err
+}
+
+// RecordAbandon - Clôt une partie interrompue avant la fin
+func (db *Database) RecordAbandon(gameID, reason string) error {
+	_, err := db.usersDB.Exec(
+		`UPDATE games SET stop_reason = ?, finished_at = ? WHERE id = ? AND finished_at IS NULL`,
+		reason, time.Now(), gameID,
+	)
+	return err
+}
+
+// GetUserGames - Dernières parties d'un joueur, de la plus récente à la plus ancienne
+func (db *Database) GetUserGames(userID, limit int) ([]shared.GameSummary, error) {
+	rows, err := db.usersDB.Query(
+		`SELECT g.id, g.code, g.mode, g.stop_reason, g.players, g.started_at, g.finished_at,
+			p.score, p.placement, p.new_rating - p.old_rating, p.forfeit
+		FROM game_players p JOIN games g ON g.id = p.game_id
+		WHERE p.user_id = ? ORDER BY g.started_at DESC LIMIT ?`,
+		userID, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	games := []shared.GameSummary{}
+	for rows.Next() {
+		var g shared.GameSummary
+		var finishedAt sql.NullTime
+		if err := rows.Scan(&g.GameID, &g.Code, &g.Mode, &g.StopReason, &g.Players, &g.StartedAt, &finishedAt,
+			&g.Score, &g.Placement, &g.RatingDelta, &g.Forfeit); err != nil {
+			return nil, err
+		}
+		if finishedAt.Valid {
+			g.FinishedAt = &finishedAt.Time
+		}
+		games = append(games, g)
+	}
+	return games, rows.Err()
+}
+
+// GetGameTranscript - Déroulé complet d'une partie : participants, questions et actions
+func (db *Database) GetGameTranscript(gameID string) (*shared.GameTranscript, error) {
+	t := &shared.GameTranscript{
+		Players:   []shared.TranscriptPlayer{},
+		Questions: []shared.TranscriptQuestion{},
+		Answers:   []shared.TranscriptAnswer{},
+	}
+	var finishedAt sql.NullTime
+	err := db.usersDB.QueryRow(
+		`SELECT id, code, mode, stop_reason, players, started_at, finished_at FROM games WHERE id = ?`, gameID,
+	).Scan(&t.Game.GameID, &t.Game.Code, &t.Game.Mode, &t.Game.StopReason, &t.Game.Players, &t.Game.StartedAt, &finishedAt)
+	if err != nil {
+		return nil, err
+	}
+	if finishedAt.Valid {
+		t.Game.FinishedAt = &finishedAt.Time
+	}
+
+	rows, err := db.usersDB.Query(
+		`SELECT p.user_id, u.username, p.score, p.placement, p.old_rating, p.new_rating, p.forfeit
+		FROM game_players p JOIN users u ON u.id = p.user_id
+		WHERE p.game_id = ? ORDER BY p.forfeit, p.placement, u.username`, gameID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var p shared.TranscriptPlayer
+		if err := rows.Scan(&p.UserID, &p.Username, &p.Score, &p.Placement, &p.OldRating, &p.NewRating, &p.Forfeit); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		t.Players = append(t.Players, p)
+	}
+	rows.Close()
+
+	rows, err = db.usersDB.Query(
+		`SELECT round, round_name, question_id, text, options, correct_answer, asked_at
+		FROM game_questions WHERE game_id = ? ORDER BY round, asked_at`, gameID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var q shared.TranscriptQuestion
+		var options string
+		if err := rows.Scan(&q.Round, &q.RoundName, &q.QuestionID, &q.Text, &options, &q.CorrectAnswer, &q.AskedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if options != "" {
+			q.Options = strings.Split(options, "|")
+		}
+		t.Questions = append(t.Questions, q)
+	}
+	rows.Close()
+
+	rows, err = db.usersDB.Query(
+		`SELECT round, question_id, user_id, kind, answer, correct, response_ms, points, score, answered_at
+		FROM game_answers WHERE game_id = ? ORDER BY id`, gameID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a shared.TranscriptAnswer
+		if err := rows.Scan(&a.Round, &a.QuestionID, &a.UserID, &a.Kind, &a.Answer, &a.Correct,
+			&a.ResponseMs, &a.Points, &a.Score, &a.AnsweredAt); err != nil {
+			return nil, err
+		}
+		t.Answers = append(t.Answers, a)
+	}
+	return t, rows.Err()
+}
+
+// SAUVEGARDES DES PARTIES EN COURS
+// SaveSnapshot - Remplace la sauvegarde d'une partie
+func (db *Database) SaveSnapshot(gameID string, data []byte) error {
+	_, err := db.usersDB.Exec(
+		`INSERT INTO game_snapshots (game_id, data, saved_at) VALUES (?, ?, ?)
+		ON CONFLICT (game_id) DO UPDATE SET data = excluded.data, saved_at = excluded.saved_at`,
+		gameID, string(data), time.Now(),
+	)
+	return err
+}
+
+// DeleteSnapshot - Supprime la sauvegarde d'une partie terminée
+func (db *Database) DeleteSnapshot(gameID string) error {
+	_, err := db.usersDB.Exec(`DELETE FROM game_snapshots WHERE game_id = ?`, gameID)
+	return err
+}
+
+// LoadSnapshots - Toutes les sauvegardes, des plus anciennes aux plus récentes
+func (db *Database) LoadSnapshots() ([][]byte, error) {
+	rows, err := db.usersDB.Query(`SELECT data FROM game_snapshots ORDER BY saved_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snapshots [][]byte
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, []byte(data))
+	}
+	return snapshots, rows.Err()
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRecordGameEndIdempotent(t *testing.T) {
+	useTestDB(t)
+	before := map[int]int{}
+	for _, id := range []int{1, 2} {
+		user, err := DB.GetUserByID(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		before[id] = user.GamesPlayed
+	}
+
+	rec := GameRecord{
+		ID:         "partie-test",
+		Code:       "ABCDEF",
+		Mode:       "multi",
+		Scores:     map[int]int{1: 300, 2: 100},
+		Placements: map[int]int{1: 1, 2: 2},
+		Rated:      true,
+	}
+	first, err := DB.RecordGameEnd(rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first[1].Delta() <= 0 || first[2].Delta() >= 0 {
+		t.Fatalf("classements après une victoire du joueur 1 : %v", first)
+	}
+
+	// La fin de partie renvoyée une seconde fois ne compte pas deux fois
+	second, err := DB.RecordGameEnd(rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("second enregistrement : %v, attendu %v", second, first)
+	}
+	for id, played := range before {
+		user, err := DB.GetUserByID(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if user.GamesPlayed != played+1 || user.Rating != first[id].New {
+			t.Errorf("joueur %d : %d parties, classement %d, attendu %d parties, classement %d",
+				id, user.GamesPlayed, user.Rating, played+1, first[id].New)
+		}
+	}
+}
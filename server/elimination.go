@@ -0,0 +1,164 @@
+package server
+
+import (
+	"log"
+	"net"
+	"quiz-app-fyne/shared"
+	"sort"
+)
+
+// eliminationEnabled indique si la partie se joue à élimination.
+// L'appelant doit détenir game.Mutex.
+func (game *Game) eliminationEnabled() bool {
+	return game.Settings.Elimination
+}
+
+// isActive indique si un joueur est encore en lice (il n'a pas été éliminé).
+// L'appelant doit détenir game.Mutex.
+func (game *Game) isActive(userID int) bool {
+	return game.Eliminated[userID] == 0
+}
+
+// activePlayers renvoie les joueurs encore en lice, triés par identifiant.
+// L'appelant doit détenir game.Mutex.
+func (game *Game) activePlayers() []int {
+	ids := make([]int, 0, len(game.Players))
+	for id := range game.Players {
+		if game.isActive(id) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// sortByStanding trie des joueurs du mieux placé au moins bien placé : score, puis
+// temps cumulé des bonnes réponses (le plus rapide devant), puis identifiant.
+// L'appelant doit détenir game.Mutex.
+func (game *Game) sortByStanding(ids []int) {
+	sort.SliceStable(ids, func(i, j int) bool {
+		a, b := ids[i], ids[j]
+		if game.Scores[a] != game.Scores[b] {
+			return game.Scores[a] > game.Scores[b]
+		}
+		if game.AnswerMs[a] != game.AnswerMs[b] {
+			return game.AnswerMs[a] < game.AnswerMs[b]
+		}
+		return a < b
+	})
+}
+
+// eliminate élimine les derniers joueurs en lice et renvoie leurs identifiants.
+// Deux joueurs au moins restent pour le duel final, qui élimine le perdant.
+// Les éliminés prennent les dernières places encore libres.
+// L'appelant doit détenir game.Mutex.
+func (game *Game) eliminate() []int {
+	active := game.activePlayers()
+	n := len(active)
+	if n <= 1 {
+		return nil
+	}
+	count := game.Settings.EliminatePerBlock
+	if count < 1 {
+		count = 1
+	}
+	if n == 2 {
+		count = 1
+	} else if count > n-2 {
+		count = n - 2
+	}
+
+	game.sortByStanding(active)
+	out := active[n-count:]
+	for i, id := range out {
+		game.Eliminated[id] = n - count + 1 + i
+	}
+	return out
+}
+
+// eliminationPlacements renvoie la place de chaque joueur : les éliminés gardent la
+// place obtenue à leur élimination, les joueurs en lice se partagent les premières.
+// L'appelant doit détenir game.Mutex.
+func (game *Game) eliminationPlacements() map[int]int {
+	places := make(map[int]int, len(game.Players))
+	active := game.activePlayers()
+	game.sortByStanding(active)
+	for i, id := range active {
+		places[id] = i + 1
+	}
+	for id, place := range game.Eliminated {
+		if _, ok := game.Players[id]; ok && place > 0 {
+			places[id] = place
+		}
+	}
+	return places
+}
+
+// eliminateAfterRound applique l'élimination à la fin de la manche index et prévient
+// les joueurs. Tant que plusieurs joueurs restent en lice, une nouvelle manche est
+// ajoutée si besoin (les types de manches des paramètres sont repris dans l'ordre).
+// Renvoie false quand la partie doit s'arrêter faute d'adversaires.
+func (gm *GameManager) eliminateAfterRound(conn *net.UDPConn, game *Game, index int) bool {
+	game.Mutex.Lock()
+	defer game.Mutex.Unlock()
+
+	if !game.eliminationEnabled() {
+		return true
+	}
+	ctx := &RoundContext{Conn: conn, Game: game}
+
+	out := game.eliminate()
+	active := game.activePlayers()
+	payload := shared.EliminationPayload{
+		Round:      index + 1,
+		Eliminated: out,
+		Names:      []string{},
+		Remaining:  len(active),
+	}
+	for _, id := range out {
+		payload.Names = append(payload.Names, game.Players[id].Username)
+	}
+	if len(active) == 1 {
+		payload.WinnerID = active[0]
+	}
+	if len(out) > 0 {
+		log.Printf("💀 Partie %s - manche %d : %v éliminé(s), %d en lice", game.Code, index+1, payload.Names, len(active))
+	}
+	ctx.Broadcast(shared.Message{Type: shared.MsgEliminated, Payload: payload})
+	ctx.BroadcastScores()
+
+	if len(active) <= 1 {
+		return false
+	}
+	if index < len(game.Rounds)-1 {
+		return true
+	}
+
+	// Manche supplémentaire : la partie continue jusqu'au vainqueur
+	kind := extraRoundKind(game.Settings.Rounds, index+1)
+	round, err := NewRound(kind)
+	if err == nil {
+		err = round.Prepare(game)
+	}
+	if err != nil {
+		log.Printf("⚠️ Partie %s - manche supplémentaire %s impossible: %v", game.Code, kind, err)
+		return false
+	}
+	game.Rounds = append(game.Rounds, round)
+	return true
+}
+
+// extraRoundKind choisit le type de la manche supplémentaire d'index n en reprenant
+// dans l'ordre les manches des paramètres, sauf la mise finale qui ne se joue qu'une fois
+func extraRoundKind(kinds []string, n int) string {
+	var ordinary []string
+	for _, kind := range kinds {
+		if kind != shared.RoundWager {
+			ordinary = append(ordinary, kind)
+		}
+	}
+	if len(ordinary) == 0 {
+		return shared.RoundQCM
+	}
+	return ordinary[n%len(ordinary)]
+}
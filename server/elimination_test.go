@@ -0,0 +1,131 @@
+package server
+
+import (
+	"quiz-app-fyne/shared"
+	"reflect"
+	"testing"
+)
+
+// eliminationGame construit une partie à élimination avec les scores donnés
+func eliminationGame(perBlock int, scores, answerMs, eliminated map[int]int) *Game {
+	game := &Game{
+		Settings:   shared.GameSettings{Elimination: true, EliminatePerBlock: perBlock},
+		Players:    make(map[int]*shared.User),
+		Scores:     scores,
+		AnswerMs:   answerMs,
+		Eliminated: eliminated,
+	}
+	for id := range scores {
+		game.Players[id] = &shared.User{ID: id}
+	}
+	if game.AnswerMs == nil {
+		game.AnswerMs = make(map[int]int)
+	}
+	if game.Eliminated == nil {
+		game.Eliminated = make(map[int]int)
+	}
+	return game
+}
+
+func TestEliminate(t *testing.T) {
+	tests := []struct {
+		name       string
+		perBlock   int
+		scores     map[int]int
+		answerMs   map[int]int
+		eliminated map[int]int
+		want       []int
+		wantPlaces map[int]int
+	}{
+		{
+			name:       "le dernier sort",
+			perBlock:   1,
+			scores:     map[int]int{1: 30, 2: 20, 3: 10, 4: 0},
+			want:       []int{4},
+			wantPlaces: map[int]int{4: 4},
+		},
+		{
+			name:       "deux par manche",
+			perBlock:   2,
+			scores:     map[int]int{1: 30, 2: 20, 3: 10, 4: 0},
+			want:       []int{3, 4},
+			wantPlaces: map[int]int{3: 3, 4: 4},
+		},
+		{
+			name:       "deux joueurs restent pour le duel",
+			perBlock:   3,
+			scores:     map[int]int{1: 30, 2: 20, 3: 10, 4: 0},
+			want:       []int{3, 4},
+			wantPlaces: map[int]int{3: 3, 4: 4},
+		},
+		{
+			name:       "le duel n'élimine que le perdant",
+			perBlock:   2,
+			scores:     map[int]int{1: 10, 2: 20},
+			want:       []int{1},
+			wantPlaces: map[int]int{1: 2},
+		},
+		{
+			name:       "égalité départagée par le temps de réponse",
+			perBlock:   1,
+			scores:     map[int]int{1: 10, 2: 10, 3: 10},
+			answerMs:   map[int]int{1: 500, 2: 300, 3: 300},
+			want:       []int{1},
+			wantPlaces: map[int]int{1: 3},
+		},
+		{
+			name:       "égalité parfaite départagée par l'identifiant",
+			perBlock:   1,
+			scores:     map[int]int{1: 10, 2: 10, 3: 10},
+			want:       []int{3},
+			wantPlaces: map[int]int{3: 3},
+		},
+		{
+			name:       "les éliminés gardent leur place",
+			perBlock:   1,
+			scores:     map[int]int{1: 30, 2: 20, 3: 10, 4: 50},
+			eliminated: map[int]int{4: 4},
+			want:       []int{3},
+			wantPlaces: map[int]int{3: 3, 4: 4},
+		},
+		{
+			name:       "vainqueur déjà désigné",
+			perBlock:   1,
+			scores:     map[int]int{1: 30, 2: 20},
+			eliminated: map[int]int{2: 2},
+			want:       nil,
+			wantPlaces: map[int]int{2: 2},
+		},
+	}
+	for _, tt := range tests {
+		game := eliminationGame(tt.perBlock, tt.scores, tt.answerMs, tt.eliminated)
+		if got := game.eliminate(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s : eliminate = %v, attendu %v", tt.name, got, tt.want)
+		}
+		if !reflect.DeepEqual(game.Eliminated, tt.wantPlaces) {
+			t.Errorf("%s : places des éliminés %v, attendu %v", tt.name, game.Eliminated, tt.wantPlaces)
+		}
+	}
+}
+
+func TestExtraRoundKind(t *testing.T) {
+	kinds := []string{shared.RoundQCM, shared.RoundRiddle, shared.RoundTimeAttack}
+	want := []string{shared.RoundQCM, shared.RoundRiddle, shared.RoundTimeAttack, shared.RoundQCM}
+	for n, kind := range want {
+		if got := extraRoundKind(kinds, n); got != kind {
+			t.Errorf("manche supplémentaire %d : %s, attendu %s", n, got, kind)
+		}
+	}
+}
+
+func TestExtraRoundKindSkipsWager(t *testing.T) {
+	kinds := []string{shared.RoundQCM, shared.RoundRiddle, shared.RoundWager}
+	for n := 0; n < 4; n++ {
+		if got := extraRoundKind(kinds, n); got == shared.RoundWager {
+			t.Errorf("manche supplémentaire %d jouée en mise finale", n)
+		}
+	}
+	if got := extraRoundKind([]string{shared.RoundWager}, 1); got != shared.RoundQCM {
+		t.Errorf("mise finale seule : manche supplémentaire %s, attendu %s", got, shared.RoundQCM)
+	}
+}
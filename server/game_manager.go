@@ -1,35 +1,65 @@
 package server
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"fmt"
 	"log"
-	"math/rand"
+	"math/big"
 	"net"
 	"quiz-app-fyne/shared"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 )
 
 type Game struct {
-	Code          string
-	Players       map[int]*shared.User
-	Questions     []shared.Question
-	Riddle        *shared.Riddle
-	Scores        map[int]int
-	Mode          string
-	AnswerChan    chan int
-	Mutex         sync.Mutex
-	CurrentManche int
-	// ===== MANCHE 2 : COURSE CONTRE LA MONTRE =====
-	Manche2Questions     []shared.Question
-	Manche2StartTime     time.Time
-	Manche2Duration      time.Duration
-	CurrentQuestionIndex map[int]int
-	StartTimerLaunched   bool
-	RiddleAnswers        map[int]bool
+	ID       string // Identifiant unique, jamais réutilisé (contrairement au code)
+	Code     string
+	Players  map[int]*shared.User
+	Scores   map[int]int
+	Mode     string
+	Settings shared.GameSettings
+	State    string // shared.GameStateLobby, GameStateInRound...
+	// Raison d'un arrêt anticipé : les manches restantes ne sont pas jouées
+	StopReason string
+	Mutex      sync.Mutex
+	// ===== MANCHES =====
+	Rounds       []Round                // Manches jouées dans l'ordre
+	CurrentRound int                    // Index de la manche en cours (-1 hors manche)
+	RoundResults []RoundResult          // Résultats des manches terminées
+	HintSpent    map[int]int            // Points dépensés en indices par joueur
+	PowerUpsUsed map[int]map[string]int // Jokers utilisés par joueur (voir powerups.go)
+	// ===== LOBBY =====
+	HostID       int
+	Ready        map[int]bool // Joueurs prêts
+	Locked       bool         // Salle fermée aux nouveaux joueurs
+	PasswordHash string       // Empreinte du mot de passe d'une salle privée (vide = sans mot de passe)
+	Public       bool         // Salle listée dans LIST_ROOMS
+	Kicked       map[int]bool // Joueurs exclus par l'hôte
+	Teams        map[int]int  // Équipe de chaque joueur (mode équipes, voir teams.go)
+	CountdownEnd time.Time    // Heure de lancement prévue pendant le compte à rebours
+	countdownID  int          // Identifie le compte à rebours actif (incrémenté à chaque annulation)
+	resumed      bool         // Manche en cours restaurée après un redémarrage (voir snapshot.go)
+	// ===== ELIMINATION =====
+	Eliminated map[int]int // Place finale des joueurs éliminés, devenus spectateurs (voir elimination.go)
+	AnswerMs   map[int]int // Temps cumulé des réponses qui ont rapporté des points (départage)
+	// ===== MORT SUBITE =====
+	TieBreaks map[int]int // Rang obtenu en mort subite parmi les joueurs à égalité (voir sudden_death.go)
+	// ===== CLASSEMENT EN DIRECT =====
+	Streaks    map[int]int // Bonnes réponses consécutives par joueur
+	LastScores map[int]int // Scores lors du dernier SCORE_UPDATE (calcul du delta)
 }
 
+// Tentatives d'enregistrement des résultats en fin de partie
+const (
+	recordAttempts   = 3
+	recordRetryDelay = 500 * time.Millisecond
+)
+
 type GameManager struct {
 	Games map[string]*Game
 	Mutex sync.RWMutex
@@ -40,36 +70,95 @@ var Manager = &GameManager{
 	Games: make(map[string]*Game),
 }
 
-func (gm *GameManager) CreateGame(host *shared.User) *Game {
+// Alphabet des codes de salle, sans caractères ambigus (0/O, 1/I/L)
+const (
+	gameCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+	gameCodeLength   = 6
+)
+
+// newGameCode tire un code de salle aléatoire et imprévisible
+func newGameCode() string {
+	b := make([]byte, gameCodeLength)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(gameCodeAlphabet))))
+		if err != nil {
+			log.Fatalf("❌ Générateur aléatoire indisponible : %v", err)
+		}
+		b[i] = gameCodeAlphabet[n.Int64()]
+	}
+	return string(b)
+}
+
+// newGameID tire l'identifiant unique d'une partie
+func newGameID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		log.Fatalf("❌ Générateur aléatoire indisponible : %v", err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// hashRoomPassword calcule l'empreinte du mot de passe d'une salle, salée par
+// l'identifiant de la partie : le mot de passe lui-même n'est jamais conservé
+func hashRoomPassword(gameID, password string) string {
+	if password == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(gameID + ":" + password))
+	return hex.EncodeToString(sum[:])
+}
+
+// NormalizeGameCode met en forme un code saisi par un joueur
+func NormalizeGameCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (gm *GameManager) CreateGame(host *shared.User, settings shared.GameSettings, password string, public bool) *Game {
 	gm.Mutex.Lock()
 	defer gm.Mutex.Unlock()
 
 	var code string
 	for {
-		code = fmt.Sprintf("%04d", rand.Intn(10000))
+		code = newGameCode()
 		if _, exists := gm.Games[code]; !exists {
 			break
 		}
 	}
 
+	id := newGameID()
 	game := &Game{
-		Code:                 code,
-		Players:              make(map[int]*shared.User),
-		Scores:               make(map[int]int),
-		AnswerChan:           make(chan int, 10),
-		CurrentQuestionIndex: make(map[int]int),
-		RiddleAnswers:        make(map[int]bool),
+		ID:           id,
+		Code:         code,
+		Players:      make(map[int]*shared.User),
+		Scores:       make(map[int]int),
+		Settings:     settings,
+		State:        shared.GameStateLobby,
+		HostID:       host.ID,
+		PasswordHash: hashRoomPassword(id, password),
+		Public:       public,
+		Ready:        make(map[int]bool),
+		Kicked:       make(map[int]bool),
+		Teams:        make(map[int]int),
+		Eliminated:   make(map[int]int),
+		AnswerMs:     make(map[int]int),
+		TieBreaks:    make(map[int]int),
+		CurrentRound: -1,
+		HintSpent:    make(map[int]int),
+		PowerUpsUsed: make(map[int]map[string]int),
+		Streaks:      make(map[int]int),
+		LastScores:   make(map[int]int),
 	}
 
 	game.Players[host.ID] = host
 	game.Scores[host.ID] = 0
+	game.assignTeam(host.ID)
 	gm.Games[code] = game
 
 	log.Printf("✅ Partie créée %s host: %s", code, host.Email)
 	return game
 }
 
-func (gm *GameManager) JoinGame(code string, player *shared.User) (*Game, error) {
+func (gm *GameManager) JoinGame(code, password string, player *shared.User) (*Game, error) {
 	gm.Mutex.Lock()
 	game, exists := gm.Games[code]
 	gm.Mutex.Unlock()
@@ -84,9 +173,25 @@ func (gm *GameManager) JoinGame(code string, player *shared.User) (*Game, error)
 	if _, alreadyJoined := game.Players[player.ID]; alreadyJoined {
 		return game, nil
 	}
+	if !game.isJoinable() {
+		return nil, fmt.Errorf("la partie a déjà commencé")
+	}
+	if game.Kicked[player.ID] {
+		return nil, fmt.Errorf("tu as été exclu de cette partie")
+	}
+	if game.Locked {
+		return nil, fmt.Errorf("la salle est verrouillée")
+	}
+	if subtle.ConstantTimeCompare([]byte(game.PasswordHash), []byte(hashRoomPassword(game.ID, password))) != 1 {
+		return nil, fmt.Errorf("mot de passe incorrect")
+	}
+	if len(game.Players) >= game.Settings.MaxPlayers {
+		return nil, fmt.Errorf("la salle est pleine (%d joueurs)", game.Settings.MaxPlayers)
+	}
 
 	game.Players[player.ID] = player
 	game.Scores[player.ID] = 0
+	game.assignTeam(player.ID)
 
 	log.Printf("✅ Joueur %s (%d) a rejoint la partie %s", player.Email, player.ID, code)
 	return game, nil
@@ -101,18 +206,42 @@ func (gm *GameManager) StartGame(code string) error {
 		return fmt.Errorf("partie introuvable")
 	}
 
-	questionsManche1, err := DB.GetRandomQuestionsForManche1()
-	if err != nil {
-		return fmt.Errorf("échec chargement manche 1: %v", err)
+	game.Mutex.Lock()
+	defer game.Mutex.Unlock()
+
+	// Une partie ne démarre qu'une seule fois
+	if !game.isJoinable() {
+		return fmt.Errorf("la partie ne peut pas démarrer (état %s)", game.State)
 	}
-	game.Questions = questionsManche1
 
-	riddle, err := DB.GetRandomRiddle()
-	if err != nil {
-		log.Printf("⚠️ Aucune devinette disponible: %v", err)
-	} else {
-		game.Riddle = riddle
+	if len(game.Rounds) == 0 {
+		for _, kind := range game.Settings.Rounds {
+			round, err := NewRound(kind)
+			if err != nil {
+				return err
+			}
+			game.Rounds = append(game.Rounds, round)
+		}
+	}
+
+	// Préparation des manches : une manche sans contenu est ignorée
+	var rounds []Round
+	for _, round := range game.Rounds {
+		if err := round.Prepare(game); err != nil {
+			log.Printf("⚠️ Manche %s ignorée: %v", round.Name(), err)
+			continue
+		}
+		rounds = append(rounds, round)
+	}
+	if len(rounds) == 0 {
+		return fmt.Errorf("aucune manche disponible")
+	}
+	game.Rounds = rounds
+	game.prepareTeams()
+	if err := game.transition(shared.GameStateInRound); err != nil {
+		return err
 	}
+	game.recordStart()
 
 	log.Printf("🚀 Partie %s démarrée avec %d joueurs", code, len(game.Players))
 	return nil
@@ -127,251 +256,235 @@ func (gm *GameManager) RunGame(conn *net.UDPConn, code string) {
 		return
 	}
 
+	gm.playRounds(conn, game, 0)
+}
+
+// playRounds joue les manches à partir de first puis termine la partie
+func (gm *GameManager) playRounds(conn *net.UDPConn, game *Game, first int) {
+	code := game.Code
 	// sauvegarder la connexion dans GameManager pour l'utiliser ailleurs
 	gm.Conn = conn
 
-	// Manche 1 : QCM classique
-	log.Printf("🎮 Partie %s - Début Manche 1 (QCM)", code)
-	for i, q := range game.Questions {
-		log.Printf("📝 Question %d/%d envoyée", i+1, len(game.Questions))
-		gm.sendQuestionToAll(conn, game, q)
-		gm.waitForAnswersOrTimeout(game, q.ID, 10*time.Second)
-	}
-	if false {
-		// ==================
-		// Manche 2 : QCM contre-la-montre 60s
-		// ==================
-		log.Printf("🎮 Partie %s - Début Manche 2 (Contre-la-montre)", code)
-
-		questionsManche2, err := DB.GetRandomQuestionsForManche2()
-		if err != nil {
-			log.Println("❌ Impossible de charger la manche 2")
+	for i := first; i < len(game.Rounds); i++ {
+		round := game.Rounds[i]
+		if err := gm.runRound(conn, game, i, round); err != nil {
+			log.Printf("⚠️ Partie %s interrompue: %v", code, err)
 			return
 		}
+		more := gm.eliminateAfterRound(conn, game, i)
 
-		game.Mutex.Lock()
-		game.Manche2Questions = questionsManche2
-		game.Manche2StartTime = time.Now()
-		game.Manche2Duration = 60 * time.Second
-		game.CurrentManche = 2
-		game.CurrentQuestionIndex = make(map[int]int)
-		for id := range game.Players {
-			game.CurrentQuestionIndex[id] = 0
-			// envoyer directement la première question
-			gm.sendNextManche2Question(conn, game, id)
+		// Affichage des résultats de la manche avant la suivante
+		if err := gm.setState(conn, game, shared.GameStateReveal, ""); err != nil {
+			log.Printf("⚠️ Partie %s interrompue: %v", code, err)
+			return
 		}
+		game.Mutex.Lock()
+		stopReason := game.StopReason
 		game.Mutex.Unlock()
-
-		// boucle de timer pour manche 2
-		ticker := time.NewTicker(200 * time.Millisecond)
-		defer ticker.Stop()
-		for {
-			<-ticker.C
-			if time.Since(game.Manche2StartTime) >= game.Manche2Duration {
-				log.Printf("⏱️ Fin Manche 2")
-				break
-			}
-		}
-	}
-
-	// Manche 3 : Devinette
-	if game.Riddle != nil {
-		log.Printf("🎮 Partie %s - Début Manche 3 (Devinette)", code)
-		gm.sendRiddleToAll(conn, game)
-		time.Sleep(60 * time.Second)
-	}
-
-	// Mise à jour des scores et fin de partie
-	log.Printf("🏁 Partie %s terminée - Mise à jour des scores", code)
-	for id, score := range game.Scores {
-		if err := DB.UpdateUserScore(id, score); err != nil {
-			log.Printf("❌ Erreur mise à jour score utilisateur %d: %v", id, err)
+		if stopReason != "" {
+			log.Printf("⏹️ Partie %s arrêtée : %s", code, stopReason)
+			break
 		}
-	}
-
-	gm.sendGameOver(conn, game)
-	go gm.cleanupGame(code)
-}
-
-// ProcessAnswer corrigé pour manche 2
-func (gm *GameManager) ProcessAnswer(userID, questionID, choice int) {
-	gm.Mutex.RLock()
-	defer gm.Mutex.RUnlock()
-
-	var game *Game
-	for _, g := range gm.Games {
-		if _, ok := g.Players[userID]; ok {
-			game = g
+		if !more {
 			break
 		}
+		if i < len(game.Rounds)-1 {
+			time.Sleep(revealDuration)
+		}
 	}
-
-	if game == nil {
-		log.Printf("⚠️ Partie introuvable pour l'utilisateur %d", userID)
+	// Égalités sur le podium départagées avant le classement final
+	if err := gm.breakTies(conn, game); err != nil {
+		log.Printf("⚠️ Partie %s interrompue: %v", code, err)
 		return
 	}
-
 	game.Mutex.Lock()
-	defer game.Mutex.Unlock()
-
-	for _, q := range game.Questions {
-		if q.ID == questionID {
-			var correct bool
-			switch choice {
-			case 0:
-				correct = q.CorrectAnswer == "A"
-			case 1:
-				correct = q.CorrectAnswer == "B"
-			case 2:
-				correct = q.CorrectAnswer == "C"
-			case 3:
-				correct = q.CorrectAnswer == "D"
-			}
-
-			if q.Manche == 1 {
-				if correct {
-					game.Scores[userID] += 15
-					log.Printf("✅ Joueur %d: +15 points (manche 1)", userID)
-				}
-			} else if q.Manche == 2 {
-				if correct {
-					game.Scores[userID] += 10
-				} else {
-					game.Scores[userID] -= 3
-				}
-				// avancer l'index et envoyer la prochaine question
-				game.CurrentQuestionIndex[userID]++
-				gm.sendNextManche2Question(gm.Conn, game, userID)
-			}
-
-			game.AnswerChan <- questionID
-			return
-		}
+	stopReason := game.StopReason
+	game.Mutex.Unlock()
+	if err := gm.setState(conn, game, shared.GameStateFinished, stopReason); err != nil {
+		log.Printf("⚠️ Partie %s interrompue: %v", code, err)
+		return
 	}
+
+	// Mise à jour des scores et fin de partie
+	log.Printf("🏁 Partie %s terminée - Mise à jour des scores", code)
+	ratings := gm.recordGameEnd(game)
+	gm.sendGameOver(conn, game, ratings)
+	game.dropSnapshot()
+	go gm.cleanupGame(code)
 }
 
-// sendNextManche2Question corrigé
-func (gm *GameManager) sendNextManche2Question(conn *net.UDPConn, game *Game, userID int) {
+// recordGameEnd enregistre les résultats de la partie. L'enregistrement est atomique
+// et idempotent : il est retenté en cas d'erreur sans risque de double comptage.
+func (gm *GameManager) recordGameEnd(game *Game) map[int]RatingChange {
 	game.Mutex.Lock()
-	defer game.Mutex.Unlock()
+	rec := GameRecord{
+		ID:         game.ID,
+		Code:       game.Code,
+		Mode:       game.Mode,
+		StopReason: game.StopReason,
+		Scores:     make(map[int]int, len(game.Players)),
+		Placements: game.placements(),
+	}
+	for id := range game.Players {
+		rec.Scores[id] = game.Scores[id]
+	}
+	rec.Rated = rec.Mode == "multi" && len(rec.Scores) >= 2
+	game.Mutex.Unlock()
 
-	if time.Since(game.Manche2StartTime) >= game.Manche2Duration {
-		// envoyer un signal "Manche terminée" au joueur
-		msg := shared.Message{
-			Type:    shared.MsgGameOver, // ou MsgManche2Finished
-			Payload: map[string]string{"message": "Manche 2 terminée"},
+	// Les dernières réponses doivent être écrites avant de clore la partie
+	History.Flush()
+	for attempt := 1; ; attempt++ {
+		ratings, err := DB.RecordGameEnd(rec)
+		if err == nil {
+			return ratings
 		}
-		if player, ok := game.Players[userID]; ok && player.Addr != nil {
-			SendResponse(conn, player.Addr, msg)
+		log.Printf("❌ Erreur enregistrement partie %s (essai %d/%d): %v", game.Code, attempt, recordAttempts, err)
+		if attempt == recordAttempts {
+			return nil
 		}
-		return
+		time.Sleep(time.Duration(attempt) * recordRetryDelay)
 	}
+}
 
-	index := game.CurrentQuestionIndex[userID]
-	if index >= len(game.Manche2Questions) {
-		return
-	}
+// runRound joue une manche jusqu'à ce que Tick signale sa fin
+func (gm *GameManager) runRound(conn *net.UDPConn, game *Game, index int, round Round) error {
+	ctx := &RoundContext{Conn: conn, Game: game}
+	log.Printf("🎮 Partie %s - Début manche %d (%s)", game.Code, index+1, round.Name())
 
-	q := game.Manche2Questions[index]
-	payload := shared.QuestionPayload{
-		Question: shared.QuestionMessage{
-			ID:      q.ID,
-			Text:    q.QuestionText,
-			Options: []string{q.ChoiceA, q.ChoiceB, q.ChoiceC, q.ChoiceD},
-			Level:   q.DifficultyLevel,
-		},
-		Manche: 2,
+	game.Mutex.Lock()
+	game.CurrentRound = index
+	// La première manche est lancée par StartGame, les suivantes après la révélation
+	if game.State != shared.GameStateInRound {
+		if err := game.transition(shared.GameStateInRound); err != nil {
+			game.Mutex.Unlock()
+			return err
+		}
 	}
-
-	msg := shared.Message{
-		Type:    shared.MsgQuestion,
-		Payload: payload,
+	game.broadcastState(conn, "")
+	if game.resumed {
+		game.resumed = false
+		game.resumeRound(ctx, round)
+	} else {
+		round.Start(ctx)
 	}
+	game.snapshot()
+	game.Mutex.Unlock()
 
-	if player, ok := game.Players[userID]; ok && player.Addr != nil {
-		SendResponse(conn, player.Addr, msg)
+	ticker := time.NewTicker(roundTickInterval)
+	defer ticker.Stop()
+	for done := false; !done; {
+		now := <-ticker.C
+		game.Mutex.Lock()
+		done = game.State != shared.GameStateInRound || game.StopReason != "" || round.Tick(ctx, now)
+		game.Mutex.Unlock()
 	}
-}
 
-func (gm *GameManager) sendQuestionToAll(
-	conn *net.UDPConn,
-	game *Game,
-	q shared.Question,
-) {
 	game.Mutex.Lock()
-	defer game.Mutex.Unlock()
-
-	payload := shared.QuestionPayload{
-		Question: shared.QuestionMessage{
-			ID:      q.ID,
-			Text:    q.QuestionText,
-			Options: []string{q.ChoiceA, q.ChoiceB, q.ChoiceC, q.ChoiceD},
-			Level:   q.DifficultyLevel,
-		},
-		Manche: q.Manche,
-	}
+	round.Finish(ctx)
+	game.RoundResults = append(game.RoundResults, round.Results())
+	game.Mutex.Unlock()
+	return nil
+}
 
-	msg := shared.Message{
-		Type:    shared.MsgQuestion,
-		Payload: payload,
-	}
+// findPlayerGame renvoie la partie en cours à laquelle participe le joueur
+// (les parties terminées restent en mémoire jusqu'à leur nettoyage)
+func (gm *GameManager) findPlayerGame(userID int) *Game {
+	gm.Mutex.RLock()
+	defer gm.Mutex.RUnlock()
 
-	for _, player := range game.Players {
-		if player.Addr != nil {
-			SendResponse(conn, player.Addr, msg)
-		} else {
-			log.Printf("⚠️ Adresse UDP manquante pour le joueur %s", player.Email)
+	for _, g := range gm.Games {
+		g.Mutex.Lock()
+		_, ok := g.Players[userID]
+		over := g.isOver()
+		g.Mutex.Unlock()
+		if ok && !over {
+			return g
 		}
 	}
+	return nil
 }
 
-func (gm *GameManager) sendRiddleToAll(conn *net.UDPConn, game *Game) {
-	payload := shared.RiddlePayload{
-		RiddleID: game.Riddle.ID,
-		Text:     game.Riddle.RiddleText,
+// HandleRoundMessage transmet un message joueur (réponse, indice...) à la manche en cours
+func (gm *GameManager) HandleRoundMessage(conn *net.UDPConn, userID int, msg shared.Message) {
+	game := gm.findPlayerGame(userID)
+	if game == nil {
+		log.Printf("⚠️ Partie introuvable pour l'utilisateur %d", userID)
+		return
 	}
 
-	msg := shared.Message{
-		Type:    shared.MsgRiddle,
-		Payload: payload,
-	}
+	game.Mutex.Lock()
+	defer game.Mutex.Unlock()
 
-	for _, player := range game.Players {
-		if player.Addr != nil {
-			SendResponse(conn, player.Addr, msg)
-		}
+	// Les réponses ne sont acceptées que pendant une manche
+	if game.State != shared.GameStateInRound || game.CurrentRound < 0 || game.CurrentRound >= len(game.Rounds) {
+		return
+	}
+	ctx := &RoundContext{Conn: conn, Game: game}
+	if !game.isActive(userID) {
+		ctx.SendError(userID, "tu es éliminé : tu suis la partie en spectateur")
+		return
 	}
+	game.Rounds[game.CurrentRound].HandleMessage(ctx, userID, msg)
 }
 
-func (gm *GameManager) sendGameOver(conn *net.UDPConn, game *Game) {
+func (gm *GameManager) sendGameOver(conn *net.UDPConn, game *Game, ratings map[int]RatingChange) {
 	game.Mutex.Lock()
 	defer game.Mutex.Unlock()
 
 	results := []shared.PlayerResult{}
 	for id, score := range game.Scores {
 		if player, exists := game.Players[id]; exists {
-			results = append(results, shared.PlayerResult{
+			result := shared.PlayerResult{
 				UserID: id,
 				Email:  player.Email,
 				Score:  score,
-			})
+			}
+			if change, ok := ratings[id]; ok {
+				result.Rating = change.New
+				result.RatingDelta = change.Delta()
+			}
+			if game.teamsEnabled() {
+				result.Team = game.Teams[id]
+			}
+			results = append(results, result)
 		}
 	}
 
+	// Le classement suit placements() (élimination, équipes, mort subite) : les joueurs
+	// à égalité partagent la même place et sont listés du plus rapide au plus lent
+	places := game.placements()
+	for i := range results {
+		results[i].Placement = places[results[i].UserID]
+	}
 	sort.Slice(results, func(i, j int) bool {
-		return results[i].Score > results[j].Score
+		a, b := results[i], results[j]
+		if a.Placement != b.Placement {
+			return a.Placement < b.Placement
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if game.AnswerMs[a.UserID] != game.AnswerMs[b.UserID] {
+			return game.AnswerMs[a.UserID] < game.AnswerMs[b.UserID]
+		}
+		return a.UserID < b.UserID
 	})
 
+	teams := game.teamStandings()
 	msg := shared.Message{
 		Type: shared.MsgGameOver,
 		Payload: shared.GameOverPayload{
 			Results: results,
+			Teams:   teams,
 		},
 	}
 
 	log.Printf("🏆 Partie %s terminée - Classement:", game.Code)
-	for i, result := range results {
-		log.Printf("  %d. %s: %d points", i+1, result.Email, result.Score)
+	for _, team := range teams {
+		log.Printf("  %d. Équipe %s: %d points", team.Rank, team.Name, team.Score)
+	}
+	for _, result := range results {
+		log.Printf("  %d. %s: %d points", result.Placement, result.Email, result.Score)
 	}
 
 	for _, player := range game.Players {
@@ -383,74 +496,6 @@ func (gm *GameManager) sendGameOver(conn *net.UDPConn, game *Game) {
 	}
 }
 
-func (gm *GameManager) SendRiddleHint(conn *net.UDPConn, userID, hintType int, addr *net.UDPAddr) {
-	gm.Mutex.RLock()
-	defer gm.Mutex.RUnlock()
-
-	var game *Game
-	for _, g := range gm.Games {
-		if _, ok := g.Players[userID]; ok {
-			game = g
-			break
-		}
-	}
-
-	if game == nil || game.Riddle == nil {
-		return
-	}
-
-	var text string
-	var cost int
-	if hintType == 1 {
-		text = game.Riddle.HintLevel1
-		cost = 25
-	} else if hintType == 2 {
-		text = game.Riddle.HintLevel2
-		cost = 50
-	} else {
-		return
-	}
-
-	game.Mutex.Lock()
-	game.Scores[userID] -= cost
-	game.Mutex.Unlock()
-
-	msg := shared.Message{
-		Type: shared.MsgRiddleHint,
-		Payload: shared.RiddleHintPayload{
-			RiddleID: game.Riddle.ID,
-			Text:     text,
-			Cost:     cost,
-		},
-	}
-
-	SendResponse(conn, addr, msg)
-}
-
-func (gm *GameManager) ProcessRiddleAnswer(userID int, answer string) {
-	gm.Mutex.RLock()
-	defer gm.Mutex.RUnlock()
-
-	var game *Game
-	for _, g := range gm.Games {
-		if _, ok := g.Players[userID]; ok {
-			game = g
-			break
-		}
-	}
-
-	if game == nil || game.Riddle == nil {
-		return
-	}
-
-	if answer == game.Riddle.CorrectWord {
-		game.Mutex.Lock()
-		game.Scores[userID] += 100
-		game.Mutex.Unlock()
-		log.Printf("🎉 Joueur %d a deviné correctement ! +100 points", userID)
-	}
-}
-
 func (gm *GameManager) cleanupGame(code string) {
 	time.Sleep(5 * time.Minute)
 
@@ -462,64 +507,3 @@ func (gm *GameManager) cleanupGame(code string) {
 		log.Printf("🧹 Partie %s nettoyée", code)
 	}
 }
-func (gm *GameManager) waitForAnswersOrTimeout(game *Game, questionID int, duration time.Duration) {
-	timer := time.NewTimer(duration)
-	defer timer.Stop()
-
-	for {
-		select {
-		case qid := <-game.AnswerChan:
-			if qid == questionID {
-				log.Printf("➡️ Question %d répondue, on passe à la suivante", questionID)
-				return
-			}
-		case <-timer.C:
-			log.Printf("⏱️ Temps écoulé pour la question %d", questionID)
-			return
-		}
-	}
-}
-func (gm *GameManager) WaitAndStartGame(game *Game) {
-	game.Mutex.Lock()
-	if game.StartTimerLaunched {
-		game.Mutex.Unlock()
-		return
-	}
-	game.StartTimerLaunched = true
-	game.Mutex.Unlock()
-
-	go func() {
-		for {
-			game.Mutex.Lock()
-			playerCount := len(game.Players)
-			game.Mutex.Unlock()
-
-			if playerCount >= 2 {
-				log.Printf("🎮 Partie %s - 2 joueurs minimum atteints, lancement dans 30s", game.Code)
-				time.Sleep(30 * time.Second)
-				gm.StartGame(game.Code)
-				gm.RunGame(gm.Conn, game.Code)
-				return
-			}
-			time.Sleep(1 * time.Second)
-		}
-	}()
-}
-func (gm *GameManager) MonitorLobby(game *Game, conn *net.UDPConn) {
-	go func() {
-		for {
-			game.Mutex.Lock()
-			playerCount := len(game.Players)
-			game.Mutex.Unlock()
-
-			if playerCount >= 2 {
-				log.Printf("🕹️ Partie %s - minimum 2 joueurs atteints, lancement dans 30s", game.Code)
-				time.Sleep(30 * time.Second)
-				gm.StartGame(game.Code)
-				go gm.RunGame(conn, game.Code) // <- utiliser conn ici
-				return
-			}
-			time.Sleep(1 * time.Second)
-		}
-	}()
-}
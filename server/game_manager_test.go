@@ -0,0 +1,49 @@
+package server
+
+import (
+	"quiz-app-fyne/shared"
+	"strings"
+	"testing"
+)
+
+func TestRoomPassword(t *testing.T) {
+	game := Manager.CreateGame(testUser(1), shared.DefaultGameSettings(), "sésame", false)
+	t.Cleanup(func() {
+		Manager.Mutex.Lock()
+		delete(Manager.Games, game.Code)
+		Manager.Mutex.Unlock()
+	})
+
+	if game.PasswordHash == "" || strings.Contains(game.PasswordHash, "sésame") {
+		t.Fatalf("empreinte du mot de passe invalide : %q", game.PasswordHash)
+	}
+	if other := hashRoomPassword("autre-partie", "sésame"); other == game.PasswordHash {
+		t.Error("même empreinte pour deux parties différentes")
+	}
+
+	for _, password := range []string{"", "Sésame", "sésame "} {
+		if _, err := Manager.JoinGame(game.Code, password, testUser(2)); err == nil {
+			t.Errorf("mot de passe %q accepté", password)
+		}
+	}
+	if _, err := Manager.JoinGame(game.Code, "sésame", testUser(2)); err != nil {
+		t.Fatalf("bon mot de passe refusé : %v", err)
+	}
+	if _, ok := game.Players[2]; !ok {
+		t.Error("joueur absent de la salle après avoir rejoint")
+	}
+}
+
+func TestRoomCapacity(t *testing.T) {
+	settings := shared.DefaultGameSettings()
+	settings.MaxPlayers = 2
+	game := newTestGame(t, settings, 1, 2)
+
+	if _, err := Manager.JoinGame(game.Code, "", testUser(3)); err == nil {
+		t.Error("joueur accepté dans une salle pleine")
+	}
+	// Un joueur déjà présent peut renvoyer sa demande
+	if _, err := Manager.JoinGame(game.Code, "", testUser(2)); err != nil {
+		t.Errorf("joueur présent refusé : %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"quiz-app-fyne/shared"
+)
+
+// Transitions autorisées entre les états d'une partie
+var gameTransitions = map[string][]string{
+	shared.GameStateLobby:     {shared.GameStateCountdown, shared.GameStateInRound, shared.GameStateAbandoned},
+	shared.GameStateCountdown: {shared.GameStateLobby, shared.GameStateInRound, shared.GameStateAbandoned},
+	shared.GameStateInRound:   {shared.GameStateReveal, shared.GameStateAbandoned},
+	shared.GameStateReveal:    {shared.GameStateInRound, shared.GameStateFinished, shared.GameStateAbandoned},
+	shared.GameStateFinished:  {},
+	shared.GameStateAbandoned: {},
+}
+
+// canTransition indique si la partie peut passer dans l'état demandé.
+// L'appelant doit détenir game.Mutex.
+func (game *Game) canTransition(to string) bool {
+	for _, allowed := range gameTransitions[game.State] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// transition change l'état de la partie si la transition est autorisée.
+// L'appelant doit détenir game.Mutex.
+func (game *Game) transition(to string) error {
+	if !game.canTransition(to) {
+		return fmt.Errorf("transition %s → %s interdite", game.State, to)
+	}
+	log.Printf("🔄 Partie %s : %s → %s", game.Code, game.State, to)
+	game.State = to
+
+	// L'entrée en manche est sauvegardée par runRound une fois la manche lancée
+	switch to {
+	case shared.GameStateAbandoned:
+		game.dropSnapshot()
+	case shared.GameStateInRound:
+	default:
+		game.snapshot()
+	}
+	return nil
+}
+
+// isJoinable indique si de nouveaux joueurs peuvent rejoindre la partie
+func (game *Game) isJoinable() bool {
+	return game.State == shared.GameStateLobby || game.State == shared.GameStateCountdown
+}
+
+// isOver indique si la partie est terminée ou abandonnée
+func (game *Game) isOver() bool {
+	return game.State == shared.GameStateFinished || game.State == shared.GameStateAbandoned
+}
+
+// stateMessage construit le message GAME_STATE courant.
+// L'appelant doit détenir game.Mutex.
+func (game *Game) stateMessage(reason string) shared.Message {
+	payload := shared.GameStatePayload{
+		GameCode:   game.Code,
+		State:      game.State,
+		RoundCount: len(game.Rounds),
+		Reason:     reason,
+	}
+	if game.CurrentRound >= 0 && game.CurrentRound < len(game.Rounds) {
+		payload.Round = game.CurrentRound + 1
+		payload.RoundName = game.Rounds[game.CurrentRound].Name()
+	}
+	return shared.Message{Type: shared.MsgGameState, Payload: payload}
+}
+
+// broadcastState envoie l'état de la partie à tous les joueurs.
+// L'appelant doit détenir game.Mutex.
+func (game *Game) broadcastState(conn *net.UDPConn, reason string) {
+	if conn == nil {
+		return
+	}
+	msg := game.stateMessage(reason)
+	for _, player := range game.Players {
+		if player.Addr != nil {
+			SendResponse(conn, player.Addr, msg)
+		}
+	}
+}
+
+// setState effectue une transition et la diffuse aux joueurs
+func (gm *GameManager) setState(conn *net.UDPConn, game *Game, to, reason string) error {
+	game.Mutex.Lock()
+	defer game.Mutex.Unlock()
+
+	if err := game.transition(to); err != nil {
+		return err
+	}
+	game.broadcastState(conn, reason)
+	return nil
+}
+
+// AbandonGame interrompt une partie qui ne peut pas continuer
+func (gm *GameManager) AbandonGame(conn *net.UDPConn, code, reason string) {
+	gm.Mutex.RLock()
+	game, ok := gm.Games[code]
+	gm.Mutex.RUnlock()
+	if !ok {
+		return
+	}
+
+	if err := gm.setState(conn, game, shared.GameStateAbandoned, reason); err != nil {
+		log.Printf("⚠️ Partie %s : abandon impossible: %v", code, err)
+		return
+	}
+	log.Printf("🛑 Partie %s abandonnée : %s", code, reason)
+	game.recordAbandon(reason)
+	go gm.cleanupGame(code)
+}
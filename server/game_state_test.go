@@ -0,0 +1,47 @@
+package server
+
+import (
+	"quiz-app-fyne/shared"
+	"testing"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{shared.GameStateLobby, shared.GameStateCountdown, true},
+		{shared.GameStateCountdown, shared.GameStateLobby, true},
+		{shared.GameStateCountdown, shared.GameStateInRound, true},
+		{shared.GameStateInRound, shared.GameStateReveal, true},
+		{shared.GameStateReveal, shared.GameStateInRound, true},
+		{shared.GameStateReveal, shared.GameStateFinished, true},
+		{shared.GameStateInRound, shared.GameStateAbandoned, true},
+		{shared.GameStateLobby, shared.GameStateReveal, false},
+		{shared.GameStateLobby, shared.GameStateFinished, false},
+		{shared.GameStateInRound, shared.GameStateLobby, false},
+		{shared.GameStateInRound, shared.GameStateFinished, false},
+		{shared.GameStateFinished, shared.GameStateLobby, false},
+		{shared.GameStateFinished, shared.GameStateAbandoned, false},
+		{shared.GameStateAbandoned, shared.GameStateLobby, false},
+	}
+	for _, tt := range tests {
+		game := &Game{State: tt.from}
+		if got := game.canTransition(tt.to); got != tt.want {
+			t.Errorf("canTransition(%s → %s) = %v, attendu %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestTransitionRefused(t *testing.T) {
+	game := &Game{Code: "ABCD", State: shared.GameStateFinished}
+	if err := game.transition(shared.GameStateInRound); err == nil {
+		t.Fatal("transition depuis une partie terminée acceptée")
+	}
+	if game.State != shared.GameStateFinished {
+		t.Errorf("état %s après un refus, attendu %s", game.State, shared.GameStateFinished)
+	}
+	if game.isJoinable() || !game.isOver() {
+		t.Errorf("partie terminée : rejoignable %v, terminée %v", game.isJoinable(), game.isOver())
+	}
+}
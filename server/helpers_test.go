@@ -0,0 +1,63 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"quiz-app-fyne/shared"
+	"testing"
+)
+
+// useTestDB remplace DB par une copie des bases livrées avec le serveur,
+// supprimée à la fin du test
+func useTestDB(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range []string{"users.db", "quiz_data.db"} {
+		data, err := os.ReadFile(filepath.Join("databases", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	db, err := NewDatabase(filepath.Join(dir, "users.db"), filepath.Join(dir, "quiz_data.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := DB
+	DB = db
+	t.Cleanup(func() {
+		History.Flush()
+		DB = previous
+		db.Close()
+	})
+}
+
+// newTestGame crée une partie multijoueur dont le premier joueur est l'hôte.
+// Les joueurs n'ont pas d'adresse UDP : aucun message n'est envoyé.
+func newTestGame(t *testing.T, settings shared.GameSettings, ids ...int) *Game {
+	t.Helper()
+	game := Manager.CreateGame(testUser(ids[0]), settings, "", false)
+	game.Mode = "multi"
+	t.Cleanup(func() {
+		Manager.Mutex.Lock()
+		delete(Manager.Games, game.Code)
+		Manager.Mutex.Unlock()
+	})
+	for _, id := range ids[1:] {
+		if _, err := Manager.JoinGame(game.Code, "", testUser(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return game
+}
+
+func testUser(id int) *shared.User {
+	return &shared.User{
+		ID:       id,
+		Email:    fmt.Sprintf("joueur%d@quiz.com", id),
+		Username: fmt.Sprintf("Joueur %d", id),
+	}
+}
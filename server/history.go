@@ -0,0 +1,125 @@
+package server
+
+import (
+	"log"
+	"quiz-app-fyne/shared"
+	"sync"
+	"time"
+)
+
+// Écritures d'historique en attente au maximum avant de bloquer la partie
+const historyQueueSize = 1024
+
+// historyRecorder écrit l'historique des parties en arrière-plan et dans l'ordre :
+// les manches appellent les méthodes Record* avec game.Mutex verrouillé et ne
+// doivent pas attendre SQLite.
+type historyRecorder struct {
+	once   sync.Once
+	writes chan func() error
+}
+
+var History = &historyRecorder{
+	writes: make(chan func() error, historyQueueSize),
+}
+
+func (h *historyRecorder) enqueue(write func() error) {
+	h.once.Do(func() { go h.run() })
+	h.writes <- write
+}
+
+func (h *historyRecorder) run() {
+	for write := range h.writes {
+		if err := write(); err != nil {
+			log.Printf("❌ Erreur écriture historique: %v", err)
+		}
+	}
+}
+
+// Flush attend que toutes les écritures déjà demandées soient faites
+func (h *historyRecorder) Flush() {
+	done := make(chan struct{})
+	h.enqueue(func() error {
+		close(done)
+		return nil
+	})
+	<-done
+}
+
+// recordStart enregistre la partie et ses participants au lancement.
+// L'appelant doit détenir game.Mutex.
+func (game *Game) recordStart() {
+	id, code, mode := game.ID, game.Code, game.Mode
+	players := make([]int, 0, len(game.Players))
+	for playerID := range game.Players {
+		players = append(players, playerID)
+	}
+	startedAt := time.Now()
+	History.enqueue(func() error {
+		return DB.RecordGameStart(id, code, mode, players, startedAt)
+	})
+}
+
+// RecordQuestion ajoute à l'historique une question posée pendant la manche en cours
+func (ctx *RoundContext) RecordQuestion(questionID int, text string, options []string, correct string) {
+	game := ctx.Game
+	id := game.ID
+	q := shared.TranscriptQuestion{
+		Round:         game.CurrentRound,
+		RoundName:     game.Rounds[game.CurrentRound].Name(),
+		QuestionID:    questionID,
+		Text:          text,
+		Options:       options,
+		CorrectAnswer: correct,
+		AskedAt:       time.Now(),
+	}
+	History.enqueue(func() error {
+		return DB.RecordQuestion(id, q)
+	})
+}
+
+// RecordQCM ajoute une question à choix multiples à l'historique
+func (ctx *RoundContext) RecordQCM(q shared.Question) {
+	ctx.RecordQuestion(q.ID, q.QuestionText, []string{q.ChoiceA, q.ChoiceB, q.ChoiceC, q.ChoiceD}, q.CorrectAnswer)
+}
+
+// RecordAnswer ajoute l'action d'un joueur à l'historique. La manche, le délai de
+// réponse (depuis askedAt) et le score après l'action sont complétés ici.
+func (ctx *RoundContext) RecordAnswer(a shared.TranscriptAnswer, askedAt time.Time) {
+	game := ctx.Game
+	id := game.ID
+	a.Round = game.CurrentRound
+	a.AnsweredAt = time.Now()
+	a.ResponseMs = int(a.AnsweredAt.Sub(askedAt) / time.Millisecond)
+	a.Score = game.Scores[a.UserID]
+	if a.Points > 0 {
+		game.AnswerMs[a.UserID] += a.ResponseMs
+	}
+	History.enqueue(func() error {
+		return DB.RecordAnswer(id, a)
+	})
+}
+
+// recordLeave marque l'abandon d'un participant avec le score retenu
+func (game *Game) recordLeave(userID, score int) {
+	id := game.ID
+	History.enqueue(func() error {
+		return DB.RecordLeave(id, userID, score)
+	})
+}
+
+// recordAbandon clôt dans l'historique une partie interrompue
+func (game *Game) recordAbandon(reason string) {
+	id := game.ID
+	History.enqueue(func() error {
+		return DB.RecordAbandon(id, reason)
+	})
+}
+
+// choiceLetter convertit un choix (0 à 3) en lettre pour l'historique
+func choiceLetter(choice int) string {
+	letters := []string{"A", "B", "C", "D"}
+	if choice < 0 || choice >= len(letters) {
+		return "?"
+	}
+	return letters[choice]
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"quiz-app-fyne/shared"
+	"sync"
+	"time"
+)
+
+// Estimation de la latence des joueurs, utilisée pour départager les réponses au buzzer
+const (
+	LatencyPingInterval    = 2 * time.Second        // Fréquence des PING pendant une manche buzzer
+	MaxLatencyCompensation = 300 * time.Millisecond // Aller-retour maximal pris en compte
+	latencySmoothing       = 0.25                   // Poids d'une nouvelle mesure dans la moyenne
+)
+
+type pendingPing struct {
+	seq    int
+	sentAt time.Time
+}
+
+type latencyTracker struct {
+	mutex   sync.Mutex
+	seq     int
+	pending map[int]pendingPing   // Dernier PING sans réponse de chaque joueur
+	rtt     map[int]time.Duration // Aller-retour moyen de chaque joueur
+}
+
+var Latency = &latencyTracker{
+	pending: make(map[int]pendingPing),
+	rtt:     make(map[int]time.Duration),
+}
+
+// Ping prépare un PING pour un joueur. Un PING précédent resté sans réponse est oublié.
+func (l *latencyTracker) Ping(userID int) shared.Message {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.seq++
+	l.pending[userID] = pendingPing{seq: l.seq, sentAt: time.Now()}
+	return shared.Message{
+		Type:    shared.MsgPing,
+		Payload: shared.PingPayload{Seq: l.seq},
+	}
+}
+
+// Pong enregistre la réponse d'un joueur à son dernier PING
+func (l *latencyTracker) Pong(userID, seq int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	ping, ok := l.pending[userID]
+	if !ok || ping.seq != seq {
+		return
+	}
+	delete(l.pending, userID)
+
+	sample := time.Since(ping.sentAt)
+	if sample > MaxLatencyCompensation {
+		sample = MaxLatencyCompensation
+	}
+	if rtt, ok := l.rtt[userID]; ok {
+		l.rtt[userID] = rtt + time.Duration(latencySmoothing*float64(sample-rtt))
+	} else {
+		l.rtt[userID] = sample
+	}
+}
+
+// OneWay estime le temps de trajet d'un message du joueur vers le serveur
+// (la moitié de l'aller-retour, plafonné). Sans mesure, aucune compensation.
+func (l *latencyTracker) OneWay(userID int) time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.rtt[userID] / 2
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"sort"
+)
+
+// LeaveGame retire un joueur de sa partie.
+//
+// Dans le lobby, le compte à rebours est réévalué. En cours de partie, l'abandon
+// est enregistré selon les règles de la salle (score conservé ou non, partie
+// comptée ou non) et la partie s'arrête s'il reste trop peu de joueurs.
+// Si l'hôte s'en va, le rôle passe au joueur restant ayant le plus petit identifiant.
+func (gm *GameManager) LeaveGame(conn *net.UDPConn, code string, userID int) error {
+	gm.Mutex.RLock()
+	game, ok := gm.Games[code]
+	gm.Mutex.RUnlock()
+	if !ok {
+		return fmt.Errorf("partie introuvable")
+	}
+
+	game.Mutex.Lock()
+	if _, ok := game.Players[userID]; !ok {
+		game.Mutex.Unlock()
+		return fmt.Errorf("tu n'es pas dans cette partie")
+	}
+	if game.isOver() {
+		game.Mutex.Unlock()
+		return nil
+	}
+
+	score := game.Scores[userID]
+	delete(game.Players, userID)
+	delete(game.Scores, userID)
+	delete(game.Ready, userID)
+	inProgress := !game.isJoinable()
+	if !inProgress {
+		delete(game.Teams, userID)
+	}
+
+	if game.HostID == userID && len(game.Players) > 0 {
+		ids := make([]int, 0, len(game.Players))
+		for id := range game.Players {
+			ids = append(ids, id)
+		}
+		sort.Ints(ids)
+		game.HostID = ids[0]
+		log.Printf("👑 Partie %s - l'hôte est parti, nouvel hôte : %d", code, game.HostID)
+	}
+
+	remaining := len(game.Players)
+	if inProgress && remaining > 0 && game.Mode == "multi" && len(game.activePlayers()) < MinPlayers {
+		game.StopReason = "trop peu de joueurs"
+	}
+	// Le classement en direct ne montre plus le joueur parti
+	if inProgress && remaining > 0 {
+		msg := game.scoreUpdateMessage()
+		for _, player := range game.Players {
+			if player.Addr != nil {
+				SendResponse(conn, player.Addr, msg)
+			}
+		}
+	}
+	settings := game.Settings
+	game.Mutex.Unlock()
+
+	log.Printf("🚪 Joueur %d a quitté la partie %s", userID, code)
+
+	if inProgress {
+		kept := 0
+		if settings.ForfeitKeepScore {
+			kept = score
+		}
+		game.recordLeave(userID, kept)
+		if settings.ForfeitCountsGame || kept != 0 {
+			if err := DB.RecordForfeit(userID, kept, settings.ForfeitCountsGame); err != nil {
+				log.Printf("❌ Erreur enregistrement abandon utilisateur %d: %v", userID, err)
+			}
+		}
+	}
+
+	switch {
+	case remaining == 0:
+		gm.AbandonGame(conn, code, "tous les joueurs sont partis")
+	case !inProgress:
+		gm.updateLobby(conn, game)
+	}
+	return nil
+}
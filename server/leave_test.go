@@ -0,0 +1,54 @@
+package server
+
+import (
+	"quiz-app-fyne/shared"
+	"testing"
+)
+
+func TestLeaveLobbyMigratesHost(t *testing.T) {
+	useTestDB(t)
+	game := newTestGame(t, shared.DefaultGameSettings(), 4, 2)
+
+	if err := Manager.LeaveGame(nil, game.Code, 4); err != nil {
+		t.Fatal(err)
+	}
+	if game.HostID != 2 {
+		t.Errorf("hôte %d après le départ de l'hôte, attendu 2", game.HostID)
+	}
+	if game.State != shared.GameStateLobby {
+		t.Errorf("état %s, attendu %s", game.State, shared.GameStateLobby)
+	}
+	if err := Manager.LeaveGame(nil, game.Code, 4); err == nil {
+		t.Error("un joueur déjà parti a pu quitter à nouveau la partie")
+	}
+}
+
+func TestLeaveInProgress(t *testing.T) {
+	useTestDB(t)
+	game := newTestGame(t, shared.DefaultGameSettings(), 5, 3, 4)
+	game.State = shared.GameStateInRound
+
+	// L'hôte part : le plus petit identifiant restant prend la main, la partie continue
+	if err := Manager.LeaveGame(nil, game.Code, 5); err != nil {
+		t.Fatal(err)
+	}
+	if game.HostID != 3 || game.StopReason != "" {
+		t.Fatalf("hôte %d, arrêt %q, attendu 3 et aucun arrêt", game.HostID, game.StopReason)
+	}
+
+	// Sous le minimum de joueurs, la partie s'arrête à la fin de la manche
+	if err := Manager.LeaveGame(nil, game.Code, 3); err != nil {
+		t.Fatal(err)
+	}
+	if game.HostID != 4 || game.StopReason != "trop peu de joueurs" {
+		t.Fatalf("hôte %d, arrêt %q, attendu 4 et « trop peu de joueurs »", game.HostID, game.StopReason)
+	}
+
+	// Le dernier départ abandonne la partie
+	if err := Manager.LeaveGame(nil, game.Code, 4); err != nil {
+		t.Fatal(err)
+	}
+	if game.State != shared.GameStateAbandoned {
+		t.Errorf("état %s sans joueur, attendu %s", game.State, shared.GameStateAbandoned)
+	}
+}
@@ -0,0 +1,316 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"quiz-app-fyne/shared"
+	"sort"
+	"time"
+)
+
+const (
+	MinPlayers     = 2                // Joueurs nécessaires pour lancer le compte à rebours
+	LobbyCountdown = 30 * time.Second // Délai entre le minimum atteint et le lancement
+)
+
+// updateLobby démarre ou annule le compte à rebours selon le nombre de joueurs
+// puis diffuse la liste des joueurs. Il est appelé à chaque arrivée ou départ
+// dans une salle multijoueur.
+func (gm *GameManager) updateLobby(conn *net.UDPConn, game *Game) {
+	game.Mutex.Lock()
+	defer game.Mutex.Unlock()
+
+	switch {
+	case game.State == shared.GameStateLobby && len(game.Players) >= MinPlayers:
+		if err := game.transition(shared.GameStateCountdown); err != nil {
+			return
+		}
+		game.countdownID++
+		game.CountdownEnd = time.Now().Add(LobbyCountdown)
+		game.broadcastState(conn, "")
+		log.Printf("🕹️ Partie %s - minimum %d joueurs atteints, lancement dans %s", game.Code, MinPlayers, LobbyCountdown)
+		go gm.runCountdown(conn, game, game.countdownID)
+
+	case game.State == shared.GameStateCountdown && len(game.Players) < MinPlayers:
+		if err := game.transition(shared.GameStateLobby); err != nil {
+			return
+		}
+		game.countdownID++ // le compte à rebours en cours s'arrête
+		game.broadcastState(conn, "pas assez de joueurs")
+		log.Printf("⏹️ Partie %s - compte à rebours annulé", game.Code)
+	}
+	game.broadcastLobby(conn)
+}
+
+// runCountdown diffuse chaque seconde le temps restant puis lance la partie.
+// Il s'arrête si le compte à rebours a été annulé ou remplacé entre-temps.
+func (gm *GameManager) runCountdown(conn *net.UDPConn, game *Game, id int) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		game.Mutex.Lock()
+		if game.countdownID != id || game.State != shared.GameStateCountdown {
+			game.Mutex.Unlock()
+			return
+		}
+		remaining := time.Until(game.CountdownEnd)
+		seconds := int((remaining + time.Second - 1) / time.Second)
+		if seconds < 0 {
+			seconds = 0
+		}
+		msg := shared.Message{
+			Type: shared.MsgCountdown,
+			Payload: shared.CountdownPayload{
+				GameCode: game.Code,
+				Seconds:  seconds,
+			},
+		}
+		for _, player := range game.Players {
+			if player.Addr != nil {
+				SendResponse(conn, player.Addr, msg)
+			}
+		}
+		game.Mutex.Unlock()
+
+		if remaining <= 0 {
+			break
+		}
+		<-ticker.C
+	}
+
+	if err := gm.StartGame(game.Code); err != nil {
+		log.Printf("⚠️ Partie %s non démarrée: %v", game.Code, err)
+		// Sans abandon, les joueurs resteraient bloqués sur le compte à rebours
+		game.Mutex.Lock()
+		stuck := game.countdownID == id && game.State == shared.GameStateCountdown
+		game.Mutex.Unlock()
+		if stuck {
+			gm.AbandonGame(conn, game.Code, err.Error())
+		}
+		return
+	}
+	gm.RunGame(conn, game.Code)
+}
+
+// ShortenCountdown permet à l'hôte d'avancer le lancement de la partie
+func (gm *GameManager) ShortenCountdown(code string, userID, seconds int) error {
+	gm.Mutex.RLock()
+	game, ok := gm.Games[code]
+	gm.Mutex.RUnlock()
+	if !ok {
+		return fmt.Errorf("partie introuvable")
+	}
+
+	game.Mutex.Lock()
+	defer game.Mutex.Unlock()
+
+	if game.HostID != userID {
+		return fmt.Errorf("seul l'hôte peut avancer le lancement")
+	}
+	if game.State != shared.GameStateCountdown {
+		return fmt.Errorf("aucun compte à rebours en cours")
+	}
+	if seconds < 0 {
+		seconds = 0
+	}
+	end := time.Now().Add(time.Duration(seconds) * time.Second)
+	if end.Before(game.CountdownEnd) {
+		game.CountdownEnd = end
+		log.Printf("⏩ Partie %s - lancement avancé à %ds", code, seconds)
+	}
+	return nil
+}
+
+// lobbyMessage construit la liste des joueurs du lobby.
+// L'appelant doit détenir game.Mutex.
+func (game *Game) lobbyMessage() shared.Message {
+	players := []shared.LobbyPlayer{}
+	for id, player := range game.Players {
+		players = append(players, shared.LobbyPlayer{
+			UserID:   id,
+			Username: player.Username,
+			Ready:    game.Ready[id],
+			IsHost:   id == game.HostID,
+			Team:     game.Teams[id],
+		})
+	}
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].Team != players[j].Team {
+			return players[i].Team < players[j].Team
+		}
+		if players[i].IsHost != players[j].IsHost {
+			return players[i].IsHost
+		}
+		return players[i].Username < players[j].Username
+	})
+
+	return shared.Message{
+		Type: shared.MsgLobbyUpdate,
+		Payload: shared.LobbyUpdatePayload{
+			GameCode: game.Code,
+			HostID:   game.HostID,
+			State:    game.State,
+			Locked:   game.Locked,
+			Private:  game.PasswordHash != "",
+			Public:   game.Public,
+			Players:  players,
+			Settings: game.Settings,
+		},
+	}
+}
+
+// broadcastLobby envoie la liste des joueurs à tout le lobby.
+// L'appelant doit détenir game.Mutex.
+func (game *Game) broadcastLobby(conn *net.UDPConn) {
+	if conn == nil || !game.isJoinable() {
+		return
+	}
+	msg := game.lobbyMessage()
+	for _, player := range game.Players {
+		if player.Addr != nil {
+			SendResponse(conn, player.Addr, msg)
+		}
+	}
+}
+
+// lobbyGame renvoie une partie encore dans le lobby, verrouillée.
+// L'appelant doit déverrouiller game.Mutex.
+func (gm *GameManager) lobbyGame(code string) (*Game, error) {
+	gm.Mutex.RLock()
+	game, ok := gm.Games[code]
+	gm.Mutex.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("partie introuvable")
+	}
+
+	game.Mutex.Lock()
+	if !game.isJoinable() {
+		game.Mutex.Unlock()
+		return nil, fmt.Errorf("la partie a déjà commencé")
+	}
+	return game, nil
+}
+
+// hostGame renvoie une partie du lobby dont userID est l'hôte, verrouillée.
+// L'appelant doit déverrouiller game.Mutex.
+func (gm *GameManager) hostGame(code string, userID int) (*Game, error) {
+	game, err := gm.lobbyGame(code)
+	if err != nil {
+		return nil, err
+	}
+	if game.HostID != userID {
+		game.Mutex.Unlock()
+		return nil, fmt.Errorf("action réservée à l'hôte")
+	}
+	return game, nil
+}
+
+// SetReady change le statut « prêt » d'un joueur du lobby
+func (gm *GameManager) SetReady(conn *net.UDPConn, code string, userID int, ready bool) error {
+	game, err := gm.lobbyGame(code)
+	if err != nil {
+		return err
+	}
+	defer game.Mutex.Unlock()
+
+	if _, ok := game.Players[userID]; !ok {
+		return fmt.Errorf("tu n'es pas dans cette partie")
+	}
+	game.Ready[userID] = ready
+	game.broadcastLobby(conn)
+	return nil
+}
+
+// HostStartGame lance immédiatement la partie à la demande de l'hôte
+func (gm *GameManager) HostStartGame(conn *net.UDPConn, code string, userID int) error {
+	game, err := gm.hostGame(code, userID)
+	if err != nil {
+		return err
+	}
+	players := len(game.Players)
+	game.Mutex.Unlock()
+
+	if players < MinPlayers {
+		return fmt.Errorf("%d joueurs minimum pour lancer la partie", MinPlayers)
+	}
+	if err := gm.StartGame(code); err != nil {
+		return err
+	}
+	go gm.RunGame(conn, code)
+	return nil
+}
+
+// KickPlayer exclut un joueur du lobby ; il ne pourra plus revenir dans la salle
+func (gm *GameManager) KickPlayer(conn *net.UDPConn, code string, userID, targetID int) error {
+	game, err := gm.hostGame(code, userID)
+	if err != nil {
+		return err
+	}
+	target, ok := game.Players[targetID]
+	if !ok || targetID == userID {
+		game.Mutex.Unlock()
+		return fmt.Errorf("joueur introuvable")
+	}
+
+	delete(game.Players, targetID)
+	delete(game.Scores, targetID)
+	delete(game.Ready, targetID)
+	delete(game.Teams, targetID)
+	game.Kicked[targetID] = true
+	if target.Addr != nil {
+		SendResponse(conn, target.Addr, shared.Message{
+			Type:    shared.MsgKicked,
+			Payload: shared.GameErrorPayload{Message: "L'hôte t'a exclu de la partie"},
+		})
+	}
+	game.Mutex.Unlock()
+
+	log.Printf("🚫 Partie %s - joueur %d exclu par l'hôte", code, targetID)
+	gm.updateLobby(conn, game)
+	return nil
+}
+
+// LockRoom ferme (ou rouvre) la salle aux nouveaux joueurs
+func (gm *GameManager) LockRoom(conn *net.UDPConn, code string, userID int, locked bool) error {
+	game, err := gm.hostGame(code, userID)
+	if err != nil {
+		return err
+	}
+	defer game.Mutex.Unlock()
+
+	game.Locked = locked
+	game.broadcastLobby(conn)
+	return nil
+}
+
+// SetPublic affiche (ou retire) la salle dans la liste des salles publiques
+func (gm *GameManager) SetPublic(conn *net.UDPConn, code string, userID int, public bool) error {
+	game, err := gm.hostGame(code, userID)
+	if err != nil {
+		return err
+	}
+	defer game.Mutex.Unlock()
+
+	game.Public = public
+	game.broadcastLobby(conn)
+	return nil
+}
+
+// TransferHost confie le rôle d'hôte à un autre joueur du lobby
+func (gm *GameManager) TransferHost(conn *net.UDPConn, code string, userID, targetID int) error {
+	game, err := gm.hostGame(code, userID)
+	if err != nil {
+		return err
+	}
+	defer game.Mutex.Unlock()
+
+	if _, ok := game.Players[targetID]; !ok {
+		return fmt.Errorf("joueur introuvable")
+	}
+	game.HostID = targetID
+	game.broadcastLobby(conn)
+	log.Printf("👑 Partie %s - nouvel hôte : %d", code, targetID)
+	return nil
+}
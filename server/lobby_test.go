@@ -0,0 +1,35 @@
+package server
+
+import (
+	"quiz-app-fyne/shared"
+	"testing"
+)
+
+func TestCountdownCancelledWhenPlayerLeaves(t *testing.T) {
+	useTestDB(t)
+	game := newTestGame(t, shared.DefaultGameSettings(), 1, 2)
+
+	Manager.updateLobby(nil, game)
+	if game.State != shared.GameStateCountdown {
+		t.Fatalf("état %s avec %d joueurs, attendu %s", game.State, MinPlayers, shared.GameStateCountdown)
+	}
+	started := game.countdownID
+
+	game.Mutex.Lock()
+	delete(game.Players, 2)
+	game.Mutex.Unlock()
+	Manager.updateLobby(nil, game)
+
+	if game.State != shared.GameStateLobby {
+		t.Fatalf("état %s après un départ, attendu %s", game.State, shared.GameStateLobby)
+	}
+	if game.countdownID == started {
+		t.Fatal("compte à rebours annulé sans changer d'identifiant")
+	}
+
+	// L'ancien compte à rebours rend la main sans lancer la partie
+	Manager.runCountdown(nil, game, started)
+	if game.State != shared.GameStateLobby {
+		t.Errorf("état %s après un compte à rebours périmé, attendu %s", game.State, shared.GameStateLobby)
+	}
+}
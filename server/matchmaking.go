@@ -0,0 +1,230 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"quiz-app-fyne/shared"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Réglages de la file d'attente
+const (
+	MatchSize           = 4                // Taille idéale d'une partie trouvée par la file
+	MatchInterval       = time.Second      // Fréquence des passes d'appariement
+	MatchPartialAfter   = 20 * time.Second // Au-delà, une partie peut démarrer avec MinPlayers joueurs
+	MatchBaseGap        = 100              // Écart de classement accepté à l'entrée dans la file
+	MatchGapPerSecond   = 10               // Élargissement de l'écart par seconde d'attente
+	MatchMaxGap         = 800              // Écart maximal
+	QueueStatusInterval = 5 * time.Second  // Fréquence des QUEUE_STATUS envoyés aux joueurs en attente
+)
+
+type queueEntry struct {
+	User       *shared.User
+	Rating     int
+	Since      time.Time
+	lastStatus time.Time
+}
+
+type matchmaker struct {
+	mutex   sync.Mutex
+	entries map[int]*queueEntry
+	once    sync.Once
+}
+
+var Matchmaker = &matchmaker{
+	entries: make(map[int]*queueEntry),
+}
+
+// playerRating donne le niveau utilisé pour l'appariement : le classement Elo
+func playerRating(user *shared.User) int {
+	return user.Rating
+}
+
+// gap renvoie l'écart de niveau accepté pour un joueur selon son temps d'attente
+func (e *queueEntry) gap(now time.Time) int {
+	gap := MatchBaseGap + int(now.Sub(e.Since).Seconds())*MatchGapPerSecond
+	if gap > MatchMaxGap {
+		gap = MatchMaxGap
+	}
+	return gap
+}
+
+// Enqueue place un joueur dans la file d'attente (ou rafraîchit son adresse s'il y est déjà)
+func (mm *matchmaker) Enqueue(conn *net.UDPConn, user *shared.User) error {
+	if Manager.findPlayerGame(user.ID) != nil {
+		return fmt.Errorf("tu es déjà dans une partie")
+	}
+	mm.once.Do(func() { go mm.run(conn) })
+
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
+	now := time.Now()
+	entry, ok := mm.entries[user.ID]
+	if ok {
+		entry.User.Addr = user.Addr
+	} else {
+		entry = &queueEntry{User: user, Rating: playerRating(user), Since: now}
+		mm.entries[user.ID] = entry
+		log.Printf("⏳ %s entre dans la file (niveau %d)", user.Username, entry.Rating)
+	}
+	mm.sendStatus(conn, entry, now)
+	return nil
+}
+
+// Dequeue retire un joueur de la file d'attente
+func (mm *matchmaker) Dequeue(conn *net.UDPConn, userID int, addr *net.UDPAddr) {
+	mm.mutex.Lock()
+	if _, ok := mm.entries[userID]; ok {
+		delete(mm.entries, userID)
+		log.Printf("🚪 Joueur %d quitte la file", userID)
+	}
+	mm.mutex.Unlock()
+
+	SendResponse(conn, addr, shared.Message{
+		Type:    shared.MsgQueueStatus,
+		Payload: shared.QueueStatusPayload{InQueue: false},
+	})
+}
+
+// sendStatus informe un joueur de son attente. L'appelant doit détenir mm.mutex.
+func (mm *matchmaker) sendStatus(conn *net.UDPConn, entry *queueEntry, now time.Time) {
+	entry.lastStatus = now
+	if entry.User.Addr == nil {
+		return
+	}
+	SendResponse(conn, entry.User.Addr, shared.Message{
+		Type: shared.MsgQueueStatus,
+		Payload: shared.QueueStatusPayload{
+			InQueue:   true,
+			Waiting:   int(now.Sub(entry.Since).Seconds()),
+			Queued:    len(mm.entries),
+			Rating:    entry.Rating,
+			RatingGap: entry.gap(now),
+		},
+	})
+}
+
+// run effectue une passe d'appariement à chaque intervalle
+func (mm *matchmaker) run(conn *net.UDPConn) {
+	ticker := time.NewTicker(MatchInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		for _, group := range mm.match(conn, now) {
+			mm.startMatch(conn, group)
+		}
+	}
+}
+
+// match forme les groupes de joueurs prêts à jouer ensemble et les retire de la file.
+// Les joueurs qui attendent depuis le plus longtemps choisissent en premier, parmi
+// les joueurs de niveau le plus proche. L'écart doit convenir aux deux joueurs : un
+// joueur qui vient d'arriver n'est pas apparié au-delà de son propre écart.
+func (mm *matchmaker) match(conn *net.UDPConn, now time.Time) [][]*queueEntry {
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
+	var waiting []*queueEntry
+	for id, entry := range mm.entries {
+		// Un joueur qui a rejoint une partie entre-temps n'attend plus
+		if Manager.findPlayerGame(id) != nil {
+			delete(mm.entries, id)
+			continue
+		}
+		waiting = append(waiting, entry)
+	}
+	sort.Slice(waiting, func(i, j int) bool {
+		return waiting[i].Since.Before(waiting[j].Since)
+	})
+
+	var groups [][]*queueEntry
+	matched := make(map[int]bool)
+	for _, anchor := range waiting {
+		if matched[anchor.User.ID] {
+			continue
+		}
+		gap := anchor.gap(now)
+
+		var candidates []*queueEntry
+		for _, e := range waiting {
+			if e != anchor && !matched[e.User.ID] && abs(e.Rating-anchor.Rating) <= min(gap, e.gap(now)) {
+				candidates = append(candidates, e)
+			}
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return abs(candidates[i].Rating-anchor.Rating) < abs(candidates[j].Rating-anchor.Rating)
+		})
+		if len(candidates) > MatchSize-1 {
+			candidates = candidates[:MatchSize-1]
+		}
+
+		group := append([]*queueEntry{anchor}, candidates...)
+		if len(group) < MatchSize && (len(group) < MinPlayers || now.Sub(anchor.Since) < MatchPartialAfter) {
+			continue
+		}
+		for _, e := range group {
+			matched[e.User.ID] = true
+			delete(mm.entries, e.User.ID)
+		}
+		groups = append(groups, group)
+	}
+
+	for _, entry := range mm.entries {
+		if now.Sub(entry.lastStatus) >= QueueStatusInterval {
+			mm.sendStatus(conn, entry, now)
+		}
+	}
+	return groups
+}
+
+// startMatch crée la partie d'un groupe et prévient les joueurs appariés.
+// Le compte à rebours du lobby démarre aussitôt puisque le minimum est atteint.
+func (mm *matchmaker) startMatch(conn *net.UDPConn, group []*queueEntry) {
+	host := group[0].User
+	game := Manager.CreateGame(host, shared.DefaultGameSettings(), "", false)
+	game.Mutex.Lock()
+	game.Mode = "multi"
+	game.Mutex.Unlock()
+
+	var names []string
+	for _, e := range group {
+		names = append(names, e.User.Username)
+		if e.User.ID == host.ID {
+			continue
+		}
+		if _, err := Manager.JoinGame(game.Code, "", e.User); err != nil {
+			log.Printf("⚠️ Appariement %s : %s non ajouté (%v)", game.Code, e.User.Username, err)
+		}
+	}
+
+	game.Mutex.Lock()
+	msg := shared.Message{
+		Type: shared.MsgMatchFound,
+		Payload: shared.GameJoinedPayload{
+			GameCode: game.Code,
+			Mode:     game.Mode,
+			HostID:   game.HostID,
+			Settings: game.Settings,
+		},
+	}
+	for _, player := range game.Players {
+		if player.Addr != nil {
+			SendResponse(conn, player.Addr, msg)
+		}
+	}
+	game.Mutex.Unlock()
+
+	log.Printf("🤝 Partie %s créée par la file : %v", game.Code, names)
+	Manager.updateLobby(conn, game)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
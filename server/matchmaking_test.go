@@ -0,0 +1,93 @@
+package server
+
+import (
+	"quiz-app-fyne/shared"
+	"sort"
+	"testing"
+	"time"
+)
+
+// queued décrit un joueur en attente : son niveau et depuis combien de temps il attend
+type queued struct {
+	rating int
+	wait   time.Duration
+}
+
+// queueFor construit une file d'attente telle qu'elle serait à l'instant now
+func queueFor(now time.Time, players map[int]queued) *matchmaker {
+	mm := &matchmaker{entries: make(map[int]*queueEntry)}
+	for id, p := range players {
+		mm.entries[id] = &queueEntry{
+			User:       &shared.User{ID: id, Username: testUser(id).Username},
+			Rating:     p.rating,
+			Since:      now.Add(-p.wait),
+			lastStatus: now,
+		}
+	}
+	return mm
+}
+
+func groupIDs(groups [][]*queueEntry) [][]int {
+	var ids [][]int
+	for _, group := range groups {
+		var g []int
+		for _, e := range group {
+			g = append(g, e.User.ID)
+		}
+		sort.Ints(g)
+		ids = append(ids, g)
+	}
+	return ids
+}
+
+func TestMatchFullGroup(t *testing.T) {
+	now := time.Now()
+	mm := queueFor(now, map[int]queued{
+		901: {1000, 3 * time.Second},
+		902: {1000 + MatchBaseGap/2, 2 * time.Second},
+		903: {1000 - MatchBaseGap/2, time.Second},
+		904: {1000 + MatchBaseGap, 0},
+		905: {1000 + MatchMaxGap + 1, 0},
+	})
+
+	groups := groupIDs(mm.match(nil, now))
+	if len(groups) != 1 || len(groups[0]) != MatchSize || groups[0][0] != 901 || groups[0][3] != 904 {
+		t.Fatalf("groupes %v, attendu [[901 902 903 904]]", groups)
+	}
+	if _, ok := mm.entries[905]; !ok || len(mm.entries) != 1 {
+		t.Errorf("file après appariement : %d joueurs, attendu le seul joueur 905", len(mm.entries))
+	}
+}
+
+func TestMatchPartialGroupWaits(t *testing.T) {
+	now := time.Now()
+	mm := queueFor(now, map[int]queued{
+		911: {1000, 5 * time.Second},
+		912: {1000 + MatchBaseGap/4, 5 * time.Second},
+	})
+
+	if groups := mm.match(nil, now); len(groups) != 0 {
+		t.Fatalf("groupe incomplet formé avant %s : %v", MatchPartialAfter, groupIDs(groups))
+	}
+	later := now.Add(MatchPartialAfter)
+	if groups := groupIDs(mm.match(nil, later)); len(groups) != 1 || len(groups[0]) != 2 {
+		t.Errorf("groupes %v après %s, attendu [[911 912]]", groups, MatchPartialAfter)
+	}
+}
+
+func TestMatchRespectsNewcomerGap(t *testing.T) {
+	now := time.Now()
+	mm := queueFor(now, map[int]queued{
+		921: {1000, time.Minute},
+		922: {1000 + MatchBaseGap + 5*MatchGapPerSecond, 0},
+	})
+
+	// L'écart du joueur 921 couvre 922, mais pas celui de 922 qui vient d'arriver
+	if groups := mm.match(nil, now); len(groups) != 0 {
+		t.Fatalf("joueur apparié au-delà de son écart : %v", groupIDs(groups))
+	}
+	later := now.Add(10 * time.Second)
+	if groups := groupIDs(mm.match(nil, later)); len(groups) != 1 {
+		t.Errorf("groupes %v une fois les écarts élargis, attendu [[921 922]]", groups)
+	}
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"fmt"
+	"math/rand"
+	"quiz-app-fyne/shared"
+	"sort"
+	"time"
+)
+
+// Temps ajouté par le joker « temps supplémentaire »
+const ExtraTimeBonus = 5 * time.Second
+
+// isPowerUp vérifie qu'un type de joker existe
+func isPowerUp(kind string) bool {
+	for _, k := range shared.PowerUpKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// powerUpInventory renvoie les jokers restants d'un joueur (nil si la partie se joue sans jokers).
+// L'appelant doit détenir game.Mutex.
+func (game *Game) powerUpInventory(userID int) map[string]int {
+	if game.Settings.PowerUps <= 0 {
+		return nil
+	}
+	inventory := make(map[string]int, len(shared.PowerUpKinds))
+	for _, kind := range shared.PowerUpKinds {
+		inventory[kind] = game.Settings.PowerUps - game.PowerUpsUsed[userID][kind]
+	}
+	return inventory
+}
+
+// consumePowerUp décompte l'utilisation d'un joker, refusée si le joueur n'en a plus.
+// L'appelant doit détenir game.Mutex.
+func (game *Game) consumePowerUp(userID int, kind string) error {
+	if game.Settings.PowerUps <= 0 {
+		return fmt.Errorf("la partie se joue sans jokers")
+	}
+	if !isPowerUp(kind) {
+		return fmt.Errorf("joker inconnu: %s", kind)
+	}
+	used := game.PowerUpsUsed[userID]
+	if used[kind] >= game.Settings.PowerUps {
+		return fmt.Errorf("plus de joker de ce type")
+	}
+	if used == nil {
+		used = make(map[string]int)
+		game.PowerUpsUsed[userID] = used
+	}
+	used[kind]++
+	return nil
+}
+
+// fiftyFifty tire deux mauvaises réponses à retirer d'une question QCM
+func fiftyFifty(q shared.Question) []int {
+	var wrong []int
+	for choice := 0; choice < 4; choice++ {
+		if !isCorrectChoice(q, choice) {
+			wrong = append(wrong, choice)
+		}
+	}
+	rand.Shuffle(len(wrong), func(i, j int) { wrong[i], wrong[j] = wrong[j], wrong[i] })
+	if len(wrong) > 2 {
+		wrong = wrong[:2]
+	}
+	sort.Ints(wrong)
+	return wrong
+}
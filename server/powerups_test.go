@@ -0,0 +1,62 @@
+package server
+
+import (
+	"quiz-app-fyne/shared"
+	"testing"
+)
+
+func TestPowerUpInventory(t *testing.T) {
+	game := &Game{
+		Settings:     shared.GameSettings{PowerUps: 2},
+		PowerUpsUsed: make(map[int]map[string]int),
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := game.consumePowerUp(1, shared.PowerUpFiftyFifty); err != nil {
+			t.Fatalf("joker %d refusé : %v", i+1, err)
+		}
+	}
+	if err := game.consumePowerUp(1, shared.PowerUpFiftyFifty); err == nil {
+		t.Error("troisième joker 50/50 accepté avec 2 par type")
+	}
+	if err := game.consumePowerUp(1, shared.PowerUpDouble); err != nil {
+		t.Errorf("joker d'un autre type refusé : %v", err)
+	}
+	if err := game.consumePowerUp(1, "triple"); err == nil {
+		t.Error("joker inconnu accepté")
+	}
+
+	inventory := game.powerUpInventory(1)
+	if inventory[shared.PowerUpFiftyFifty] != 0 || inventory[shared.PowerUpDouble] != 1 || inventory[shared.PowerUpSkip] != 2 {
+		t.Errorf("inventaire du joueur 1 : %v", inventory)
+	}
+	// Chaque joueur a son propre inventaire
+	if other := game.powerUpInventory(2); other[shared.PowerUpFiftyFifty] != 2 {
+		t.Errorf("inventaire du joueur 2 entamé : %v", other)
+	}
+}
+
+func TestPowerUpsDisabled(t *testing.T) {
+	game := &Game{PowerUpsUsed: make(map[int]map[string]int)}
+	if game.powerUpInventory(1) != nil {
+		t.Error("inventaire présent dans une partie sans jokers")
+	}
+	if err := game.consumePowerUp(1, shared.PowerUpSkip); err == nil {
+		t.Error("joker accepté dans une partie sans jokers")
+	}
+}
+
+func TestFiftyFifty(t *testing.T) {
+	q := shared.Question{CorrectAnswer: "C"}
+	for i := 0; i < 20; i++ {
+		removed := fiftyFifty(q)
+		if len(removed) != 2 || removed[0] >= removed[1] {
+			t.Fatalf("choix retirés %v, attendu deux choix distincts triés", removed)
+		}
+		for _, choice := range removed {
+			if isCorrectChoice(q, choice) {
+				t.Fatalf("la bonne réponse a été retirée : %v", removed)
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"math"
+	"sort"
+)
+
+// Classement Elo des parties multijoueurs
+const (
+	InitialRating = 1200 // Classement d'un nouveau joueur (valeur par défaut de users.rating)
+	RatingK       = 32   // Variation maximale face à un seul adversaire
+)
+
+// RatingChange décrit l'évolution du classement d'un joueur après une partie
+type RatingChange struct {
+	Old int
+	New int
+}
+
+func (c RatingChange) Delta() int {
+	return c.New - c.Old
+}
+
+// eloDeltas calcule la variation de classement de chaque joueur selon sa place finale.
+// Chaque paire de joueurs compte comme un duel (victoire, nul ou défaite) ; la somme
+// est ramenée à l'échelle d'un seul adversaire pour ne pas favoriser les grandes salles.
+func eloDeltas(ratings, placements map[int]int) map[int]int {
+	deltas := make(map[int]int, len(ratings))
+	if len(ratings) < 2 {
+		return deltas
+	}
+	for i, ri := range ratings {
+		var sum float64
+		for j, rj := range ratings {
+			if i == j {
+				continue
+			}
+			actual := 0.5
+			if placements[i] < placements[j] {
+				actual = 1
+			} else if placements[i] > placements[j] {
+				actual = 0
+			}
+			expected := 1 / (1 + math.Pow(10, float64(rj-ri)/400))
+			sum += actual - expected
+		}
+		deltas[i] = int(math.Round(RatingK * sum / float64(len(ratings)-1)))
+	}
+	return deltas
+}
+
+// placements renvoie la place de chaque joueur encore présent (ex æquo à la même place).
+// En mode équipes, chaque joueur prend la place de son équipe ; en mode élimination,
+// la place dépend de l'ordre d'élimination. Les joueurs à égalité de points sont
+// ordonnés par leur mort subite éventuelle.
+// L'appelant doit détenir game.Mutex.
+func (game *Game) placements() map[int]int {
+	if game.eliminationEnabled() {
+		return game.eliminationPlacements()
+	}
+	if game.teamsEnabled() {
+		places := make(map[int]int, len(game.Players))
+		for _, team := range game.teamStandings() {
+			for _, id := range team.Members {
+				places[id] = team.Rank
+			}
+		}
+		return places
+	}
+
+	var ids []int
+	for id := range game.Players {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := ids[i], ids[j]
+		if game.Scores[a] != game.Scores[b] {
+			return game.Scores[a] > game.Scores[b]
+		}
+		return game.TieBreaks[a] < game.TieBreaks[b]
+	})
+
+	places := make(map[int]int, len(ids))
+	for i, id := range ids {
+		if i > 0 && game.Scores[id] == game.Scores[ids[i-1]] && game.TieBreaks[id] == game.TieBreaks[ids[i-1]] {
+			places[id] = places[ids[i-1]]
+		} else {
+			places[id] = i + 1
+		}
+	}
+	return places
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEloDeltas(t *testing.T) {
+	tests := []struct {
+		name       string
+		ratings    map[int]int
+		placements map[int]int
+		want       map[int]int
+	}{
+		{
+			name:       "joueur seul",
+			ratings:    map[int]int{1: 1200},
+			placements: map[int]int{1: 1},
+			want:       map[int]int{},
+		},
+		{
+			name:       "duel à classement égal",
+			ratings:    map[int]int{1: 1200, 2: 1200},
+			placements: map[int]int{1: 1, 2: 2},
+			want:       map[int]int{1: 16, 2: -16},
+		},
+		{
+			name:       "match nul",
+			ratings:    map[int]int{1: 1200, 2: 1200},
+			placements: map[int]int{1: 1, 2: 1},
+			want:       map[int]int{1: 0, 2: 0},
+		},
+		{
+			name:       "victoire du favori",
+			ratings:    map[int]int{1: 1400, 2: 1200},
+			placements: map[int]int{1: 1, 2: 2},
+			want:       map[int]int{1: 8, 2: -8},
+		},
+		{
+			name:       "victoire de l'outsider",
+			ratings:    map[int]int{1: 1400, 2: 1200},
+			placements: map[int]int{1: 2, 2: 1},
+			want:       map[int]int{1: -24, 2: 24},
+		},
+		{
+			name:       "trois joueurs, ramené à un adversaire",
+			ratings:    map[int]int{1: 1200, 2: 1200, 3: 1200},
+			placements: map[int]int{1: 1, 2: 2, 3: 3},
+			want:       map[int]int{1: 16, 2: 0, 3: -16},
+		},
+		{
+			name:       "trois joueurs, ex æquo en tête",
+			ratings:    map[int]int{1: 1200, 2: 1200, 3: 1200},
+			placements: map[int]int{1: 1, 2: 1, 3: 3},
+			want:       map[int]int{1: 8, 2: 8, 3: -16},
+		},
+	}
+	for _, tt := range tests {
+		if got := eloDeltas(tt.ratings, tt.placements); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s : eloDeltas = %v, attendu %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"quiz-app-fyne/shared"
+)
+
+// Nombre de parties renvoyées par GAME_HISTORY
+const HistoryLimit = 20
+
+// Taille maximale d'un message du replay (le client lit des datagrammes de 64 Ko)
+const MaxReplayMessageSize = 60 * 1024
+
+// buildReplay découpe le déroulé d'une partie en étapes, une par question posée,
+// avec l'évolution des scores de chaque joueur
+func buildReplay(t *shared.GameTranscript) shared.ReplayPayload {
+	replay := shared.ReplayPayload{
+		Game:    t.Game,
+		Players: t.Players,
+		Steps:   []shared.ReplayStep{},
+	}
+
+	type questionKey struct{ round, id int }
+	index := make(map[questionKey]int, len(t.Questions))
+	for i, q := range t.Questions {
+		index[questionKey{q.Round, q.QuestionID}] = i
+		replay.Steps = append(replay.Steps, shared.ReplayStep{
+			Question: q,
+			Answers:  []shared.TranscriptAnswer{},
+		})
+	}
+	for _, a := range t.Answers {
+		if i, ok := index[questionKey{a.Round, a.QuestionID}]; ok {
+			replay.Steps[i].Answers = append(replay.Steps[i].Answers, a)
+		}
+	}
+
+	// Chaque action porte le score du joueur après coup : on le reporte d'étape en étape
+	scores := make(map[int]int, len(t.Players))
+	for _, p := range t.Players {
+		scores[p.UserID] = 0
+	}
+	for i := range replay.Steps {
+		for _, a := range replay.Steps[i].Answers {
+			scores[a.UserID] = a.Score
+		}
+		step := make(map[int]int, len(scores))
+		for id, score := range scores {
+			step[id] = score
+		}
+		replay.Steps[i].Scores = step
+	}
+	return replay
+}
+
+// GetReplay renvoie le replay d'une partie à l'un de ses participants
+func GetReplay(userID int, gameID string) (*shared.ReplayPayload, error) {
+	t, err := DB.GetGameTranscript(gameID)
+	if err != nil {
+		return nil, fmt.Errorf("partie introuvable")
+	}
+	for _, p := range t.Players {
+		if p.UserID == userID {
+			replay := buildReplay(t)
+			return &replay, nil
+		}
+	}
+	return nil, fmt.Errorf("tu n'as pas participé à cette partie")
+}
+
+// replayChunks découpe un replay en parties dont chaque message tient dans
+// MaxReplayMessageSize. Une étape trop volumineuse à elle seule rend le replay impossible.
+func replayChunks(replay shared.ReplayPayload) ([]shared.ReplayPayload, error) {
+	empty := replay
+	empty.Steps = []shared.ReplayStep{}
+	empty.Part, empty.Parts = MaxReplayMessageSize, MaxReplayMessageSize
+	base, err := json.Marshal(shared.Message{Type: shared.MsgReplay, Payload: empty})
+	if err != nil {
+		return nil, err
+	}
+
+	var chunks []shared.ReplayPayload
+	current := empty
+	size := len(base)
+	for _, step := range replay.Steps {
+		data, err := json.Marshal(step)
+		if err != nil {
+			return nil, err
+		}
+		// Une virgule sépare les étapes d'une même partie
+		if len(current.Steps) > 0 && size+len(data)+1 > MaxReplayMessageSize {
+			chunks = append(chunks, current)
+			current = empty
+			current.Steps = []shared.ReplayStep{}
+			size = len(base)
+		}
+		if size+len(data) > MaxReplayMessageSize {
+			return nil, fmt.Errorf("replay trop volumineux pour être envoyé")
+		}
+		current.Steps = append(current.Steps, step)
+		size += len(data) + 1
+	}
+	chunks = append(chunks, current)
+
+	for i := range chunks {
+		chunks[i].Part = i + 1
+		chunks[i].Parts = len(chunks)
+	}
+	return chunks, nil
+}
+
+// SendReplay envoie un replay au joueur, découpé en plusieurs messages si besoin
+func SendReplay(conn *net.UDPConn, addr *net.UDPAddr, replay shared.ReplayPayload) error {
+	chunks, err := replayChunks(replay)
+	if err != nil {
+		return err
+	}
+	for _, chunk := range chunks {
+		data, err := json.Marshal(shared.Message{Type: shared.MsgReplay, Payload: chunk})
+		if err != nil {
+			return err
+		}
+		if _, err := conn.WriteToUDP(data, addr); err != nil {
+			return fmt.Errorf("replay non envoyé: %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"encoding/json"
+	"quiz-app-fyne/shared"
+	"strings"
+	"testing"
+)
+
+// replayStep construit une étape dont la question compte textLen caractères
+func replayStep(id, textLen int) shared.ReplayStep {
+	return shared.ReplayStep{
+		Question: shared.TranscriptQuestion{QuestionID: id, Text: strings.Repeat("a", textLen)},
+		Answers:  []shared.TranscriptAnswer{},
+		Scores:   map[int]int{1: id * 10},
+	}
+}
+
+func testReplay(steps ...shared.ReplayStep) shared.ReplayPayload {
+	return shared.ReplayPayload{
+		Game:    shared.GameSummary{GameID: "partie-test"},
+		Players: []shared.TranscriptPlayer{{UserID: 1, Username: "Joueur 1"}},
+		Steps:   steps,
+	}
+}
+
+func TestReplayChunks(t *testing.T) {
+	var steps []shared.ReplayStep
+	for i := 1; i <= 10; i++ {
+		steps = append(steps, replayStep(i, 15*1024))
+	}
+	chunks, err := replayChunks(testReplay(steps...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) < 3 {
+		t.Fatalf("%d parties pour 150 Ko d'étapes, attendu au moins 3", len(chunks))
+	}
+
+	next := 1
+	for i, chunk := range chunks {
+		if chunk.Part != i+1 || chunk.Parts != len(chunks) {
+			t.Errorf("partie %d numérotée %d/%d", i+1, chunk.Part, chunk.Parts)
+		}
+		if chunk.Game.GameID != "partie-test" || len(chunk.Players) != 1 {
+			t.Errorf("partie %d sans l'en-tête du replay", i+1)
+		}
+		data, err := json.Marshal(shared.Message{Type: shared.MsgReplay, Payload: chunk})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data) > MaxReplayMessageSize {
+			t.Errorf("partie %d : message de %d octets, maximum %d", i+1, len(data), MaxReplayMessageSize)
+		}
+		for _, step := range chunk.Steps {
+			if step.Question.QuestionID != next {
+				t.Fatalf("étape %d reçue à la place de l'étape %d", step.Question.QuestionID, next)
+			}
+			next++
+		}
+	}
+	if next != 11 {
+		t.Errorf("%d étapes envoyées, attendu 10", next-1)
+	}
+}
+
+func TestReplayChunksEmpty(t *testing.T) {
+	chunks, err := replayChunks(testReplay())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) != 1 || chunks[0].Part != 1 || chunks[0].Parts != 1 || chunks[0].Steps == nil {
+		t.Errorf("replay sans étape : %+v, attendu une seule partie vide", chunks)
+	}
+}
+
+func TestReplayChunksMaxSize(t *testing.T) {
+	empty := testReplay()
+	empty.Steps = []shared.ReplayStep{}
+	empty.Part, empty.Parts = MaxReplayMessageSize, MaxReplayMessageSize
+	base, _ := json.Marshal(shared.Message{Type: shared.MsgReplay, Payload: empty})
+	small, _ := json.Marshal(replayStep(1, 0))
+
+	// Étape qui remplit exactement un message
+	fits := replayStep(1, MaxReplayMessageSize-len(base)-len(small))
+	chunks, err := replayChunks(testReplay(fits))
+	if err != nil {
+		t.Fatalf("étape de taille maximale refusée : %v", err)
+	}
+	data, _ := json.Marshal(shared.Message{Type: shared.MsgReplay, Payload: chunks[0]})
+	if len(chunks) != 1 || len(data) > MaxReplayMessageSize {
+		t.Errorf("%d parties, message de %d octets", len(chunks), len(data))
+	}
+
+	// Un octet de plus ne tient plus dans un message
+	tooBig := replayStep(1, MaxReplayMessageSize-len(base)-len(small)+1)
+	if _, err := replayChunks(testReplay(tooBig)); err == nil {
+		t.Error("étape plus grande qu'un message acceptée")
+	}
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"quiz-app-fyne/shared"
+	"strings"
+	"unicode"
+)
+
+// Lettres accentuées ramenées à leur forme de base
+var accentReplacer = strings.NewReplacer(
+	"à", "a", "â", "a", "ä", "a", "á", "a", "ã", "a", "å", "a",
+	"ç", "c",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"î", "i", "ï", "i", "í", "i", "ì", "i",
+	"ô", "o", "ö", "o", "ó", "o", "ò", "o", "õ", "o",
+	"ù", "u", "û", "u", "ü", "u", "ú", "u",
+	"ÿ", "y", "ý", "y",
+	"ñ", "n",
+	"œ", "oe", "æ", "ae",
+)
+
+// Articles français ignorés en début de réponse
+var frenchArticles = []string{"le", "la", "les", "l", "un", "une", "des", "du", "de", "d"}
+
+// normalizeAnswer simplifie une réponse pour la comparaison :
+// minuscules, sans accents, sans ponctuation ni article initial.
+func normalizeAnswer(answer string) string {
+	s := accentReplacer.Replace(strings.ToLower(strings.TrimSpace(answer)))
+
+	// La ponctuation (apostrophes, tirets...) sépare les mots
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	// "de la", "de l'" : on retire les articles tant qu'il reste un mot
+	for len(words) > 1 && isFrenchArticle(words[0]) {
+		words = words[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+func isFrenchArticle(word string) bool {
+	for _, a := range frenchArticles {
+		if word == a {
+			return true
+		}
+	}
+	return false
+}
+
+// editDistance calcule la distance de Levenshtein entre deux chaînes
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// matchRiddleAnswer compare une réponse aux réponses acceptées.
+// tolerance est le nombre de fautes de frappe admises pour une bonne réponse ;
+// une réponse à deux fautes de plus est considérée comme proche.
+func matchRiddleAnswer(answer string, accepted []string, tolerance int) string {
+	given := normalizeAnswer(answer)
+	if given == "" {
+		return shared.RiddleWrong
+	}
+
+	best := -1
+	for _, a := range accepted {
+		expected := normalizeAnswer(a)
+		if expected == "" {
+			continue
+		}
+		d := editDistance(given, expected)
+		// Pas de tolérance sur les mots très courts
+		if len([]rune(expected)) <= 3 && d > 0 {
+			d += tolerance
+		}
+		if best < 0 || d < best {
+			best = d
+		}
+	}
+
+	switch {
+	case best < 0:
+		return shared.RiddleWrong
+	case best <= tolerance:
+		return shared.RiddleCorrect
+	case best <= tolerance+2:
+		return shared.RiddleClose
+	default:
+		return shared.RiddleWrong
+	}
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"quiz-app-fyne/shared"
+	"testing"
+)
+
+func TestNormalizeAnswer(t *testing.T) {
+	tests := []struct {
+		answer string
+		want   string
+	}{
+		{"Paris ", "paris"},
+		{"  PARIS", "paris"},
+		{"Éléphant", "elephant"},
+		{"l'éléphant", "elephant"},
+		{"L’Éléphant", "elephant"},
+		{"De la Tour Eiffel", "tour eiffel"},
+		{"Saint-Exupéry", "saint exupery"},
+		{"Œuf", "oeuf"},
+		{"le", "le"}, // Un article seul reste la réponse
+		{"   ", ""},
+		{"?!", ""},
+		{"1984", "1984"},
+	}
+	for _, tt := range tests {
+		if got := normalizeAnswer(tt.answer); got != tt.want {
+			t.Errorf("normalizeAnswer(%q) = %q, attendu %q", tt.answer, got, tt.want)
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"chat", "chat", 0},
+		{"chat", "chats", 1},
+		{"chat", "chta", 2},
+		{"kitten", "sitting", 3},
+		{"éa", "ea", 1}, // Distance en caractères, pas en octets
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, attendu %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestMatchRiddleAnswer(t *testing.T) {
+	tests := []struct {
+		name      string
+		answer    string
+		accepted  []string
+		tolerance int
+		want      string
+	}{
+		{"espaces", "Paris ", []string{"Paris"}, 0, shared.RiddleCorrect},
+		{"accents", "elephant", []string{"Éléphant"}, 0, shared.RiddleCorrect},
+		{"article", "l'éléphant", []string{"éléphant"}, 0, shared.RiddleCorrect},
+		{"une faute tolérée", "elephnt", []string{"éléphant"}, 1, shared.RiddleCorrect},
+		{"une faute sans tolérance", "elephnt", []string{"éléphant"}, 0, shared.RiddleClose},
+		{"deux fautes tolérées", "elefant", []string{"éléphant"}, 2, shared.RiddleCorrect},
+		{"deux fautes pour une tolérée", "elefant", []string{"éléphant"}, 1, shared.RiddleClose},
+		{"quatre fautes pour une tolérée", "elfnt", []string{"éléphant"}, 1, shared.RiddleWrong},
+		{"sans rapport", "girafe", []string{"éléphant"}, 1, shared.RiddleWrong},
+		{"mot court exact", "OR", []string{"or"}, 1, shared.RiddleCorrect},
+		{"mot court sans tolérance", "ors", []string{"or"}, 1, shared.RiddleClose},
+		{"mot court trop loin", "orage", []string{"or"}, 1, shared.RiddleWrong},
+		{"réponse alternative", "satelite", []string{"Lune", "la lune", "satellite"}, 1, shared.RiddleCorrect},
+		{"réponse vide", "  ", []string{"Paris"}, 3, shared.RiddleWrong},
+		{"aucune réponse acceptée", "Paris", []string{"", "  "}, 3, shared.RiddleWrong},
+	}
+	for _, tt := range tests {
+		if got := matchRiddleAnswer(tt.answer, tt.accepted, tt.tolerance); got != tt.want {
+			t.Errorf("%s : matchRiddleAnswer(%q, %q, %d) = %s, attendu %s", tt.name, tt.answer, tt.accepted, tt.tolerance, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"quiz-app-fyne/shared"
+	"sort"
+	"time"
+)
+
+// MaxListedRooms limite la réponse LIST_ROOMS pour qu'elle tienne dans un datagramme
+const MaxListedRooms = 20
+
+// ListRooms renvoie les salles publiques que l'on peut encore rejoindre :
+// multijoueur, dans le lobby ou en compte à rebours, non verrouillées et pas pleines.
+// Les salles sur le point de démarrer apparaissent en premier.
+func (gm *GameManager) ListRooms() []shared.RoomInfo {
+	gm.Mutex.RLock()
+	defer gm.Mutex.RUnlock()
+
+	rooms := []shared.RoomInfo{}
+	for _, game := range gm.Games {
+		game.Mutex.Lock()
+		if game.Public && game.Mode == "multi" && game.isJoinable() && !game.Locked &&
+			len(game.Players) < game.Settings.MaxPlayers {
+			room := shared.RoomInfo{
+				GameCode:   game.Code,
+				Players:    len(game.Players),
+				MaxPlayers: game.Settings.MaxPlayers,
+				Private:    game.PasswordHash != "",
+				Settings:   game.Settings,
+			}
+			if host, ok := game.Players[game.HostID]; ok {
+				room.HostName = host.Username
+			}
+			if game.State == shared.GameStateCountdown {
+				room.Countdown = int((time.Until(game.CountdownEnd) + time.Second - 1) / time.Second)
+				if room.Countdown < 1 {
+					room.Countdown = 1
+				}
+			}
+			rooms = append(rooms, room)
+		}
+		game.Mutex.Unlock()
+	}
+
+	sort.Slice(rooms, func(i, j int) bool {
+		ci, cj := rooms[i].Countdown, rooms[j].Countdown
+		if (ci > 0) != (cj > 0) {
+			return ci > 0
+		}
+		if ci != cj {
+			return ci < cj
+		}
+		if rooms[i].Players != rooms[j].Players {
+			return rooms[i].Players > rooms[j].Players
+		}
+		return rooms[i].GameCode < rooms[j].GameCode
+	})
+	if len(rooms) > MaxListedRooms {
+		rooms = rooms[:MaxListedRooms]
+	}
+	return rooms
+}
@@ -0,0 +1,212 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"quiz-app-fyne/shared"
+	"sort"
+	"time"
+)
+
+// Intervalle entre deux appels à Round.Tick
+const roundTickInterval = 200 * time.Millisecond
+
+// Pause entre deux manches pour afficher les résultats
+const revealDuration = 5 * time.Second
+
+// Round représente une manche de jeu pilotée par RunGame.
+//
+// Prepare est appelé au démarrage de la partie (chargement des questions...).
+// Les autres méthodes sont appelées par le moteur avec game.Mutex verrouillé :
+// Start au début de la manche, HandleMessage pour chaque message joueur,
+// Tick périodiquement jusqu'à ce qu'il renvoie true, puis Finish.
+type Round interface {
+	Kind() string // Identifiant sous lequel la manche est enregistrée (RegisterRound)
+	Name() string
+	Prepare(game *Game) error
+	Start(ctx *RoundContext)
+	HandleMessage(ctx *RoundContext, userID int, msg shared.Message)
+	Tick(ctx *RoundContext, now time.Time) (done bool)
+	Finish(ctx *RoundContext)
+	Results() RoundResult
+}
+
+// ResumableRound est implémentée par les manches qui survivent à un redémarrage
+// du serveur (voir snapshot.go). SaveState et LoadState sérialisent la progression ;
+// offset est la durée d'arrêt du serveur, ajoutée aux échéances pour que les joueurs
+// retrouvent le temps qu'il leur restait. Resend renvoie à un joueur ce qu'il doit
+// voir à l'écran (question courante, indices...).
+type ResumableRound interface {
+	Round
+	SaveState() (json.RawMessage, error)
+	LoadState(data json.RawMessage, offset time.Duration) error
+	Resend(ctx *RoundContext, userID int)
+}
+
+// RoundResult résume les points gagnés (ou perdus) par chaque joueur pendant une manche
+type RoundResult struct {
+	Name   string
+	Points map[int]int
+}
+
+// RoundContext donne à une manche accès à la partie et au socket UDP
+type RoundContext struct {
+	Conn *net.UDPConn
+	Game *Game
+}
+
+// SendTo envoie un message à un joueur de la partie
+func (ctx *RoundContext) SendTo(userID int, msg shared.Message) {
+	if player, ok := ctx.Game.Players[userID]; ok && player.Addr != nil {
+		SendResponse(ctx.Conn, player.Addr, msg)
+	}
+}
+
+// SendError signale à un joueur que son action a été refusée
+func (ctx *RoundContext) SendError(userID int, message string) {
+	ctx.SendTo(userID, shared.Message{
+		Type:    shared.MsgGameError,
+		Payload: shared.GameErrorPayload{Message: message},
+	})
+}
+
+// Broadcast envoie un message à tous les joueurs de la partie
+func (ctx *RoundContext) Broadcast(msg shared.Message) {
+	for id := range ctx.Game.Players {
+		ctx.SendTo(id, msg)
+	}
+}
+
+// ActivePlayers renvoie les joueurs encore en lice, sans les spectateurs éliminés
+func (ctx *RoundContext) ActivePlayers() []int {
+	return ctx.Game.activePlayers()
+}
+
+// BroadcastScores envoie le classement en direct à tous les joueurs
+func (ctx *RoundContext) BroadcastScores() {
+	ctx.Broadcast(ctx.Game.scoreUpdateMessage())
+}
+
+// ===== REGISTRE DES MANCHES =====
+
+var roundFactories = map[string]func() Round{}
+
+// RegisterRound rend un type de manche disponible sous un identifiant
+func RegisterRound(kind string, factory func() Round) {
+	roundFactories[kind] = factory
+}
+
+// NewRound instancie une manche à partir de son identifiant
+func NewRound(kind string) (Round, error) {
+	factory, ok := roundFactories[kind]
+	if !ok {
+		return nil, fmt.Errorf("type de manche inconnu: %s", kind)
+	}
+	return factory(), nil
+}
+
+// RoundKinds renvoie les identifiants de manches enregistrés
+func RoundKinds() []string {
+	kinds := make([]string, 0, len(roundFactories))
+	for kind := range roundFactories {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// ===== BASE COMMUNE =====
+
+// roundBase regroupe le calcul des points gagnés pendant la manche
+type roundBase struct {
+	kind        string
+	name        string
+	startScores map[int]int
+	points      map[int]int
+}
+
+func (r *roundBase) Kind() string {
+	return r.kind
+}
+
+func (r *roundBase) Name() string {
+	return r.name
+}
+
+// begin mémorise les scores au début de la manche
+func (r *roundBase) begin(game *Game) {
+	r.startScores = make(map[int]int)
+	for id, score := range game.Scores {
+		r.startScores[id] = score
+	}
+}
+
+// end calcule les points gagnés pendant la manche
+func (r *roundBase) end(game *Game) {
+	r.points = make(map[int]int)
+	for id, score := range game.Scores {
+		r.points[id] = score - r.startScores[id]
+	}
+}
+
+func (r *roundBase) Results() RoundResult {
+	return RoundResult{Name: r.name, Points: r.points}
+}
+
+// baseState est la partie commune de l'état sauvegardé d'une manche
+type baseState struct {
+	StartScores map[int]int `json:"start_scores"`
+	Points      map[int]int `json:"points"`
+}
+
+func (r *roundBase) saveBase() baseState {
+	return baseState{StartScores: r.startScores, Points: r.points}
+}
+
+func (r *roundBase) loadBase(s baseState) {
+	r.startScores = s.StartScores
+	r.points = s.Points
+}
+
+// shiftTime décale une échéance de la durée d'arrêt du serveur
+func shiftTime(t time.Time, offset time.Duration) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return t.Add(offset)
+}
+
+// decodePayload convertit le payload générique d'un message dans le type attendu
+func decodePayload(msg shared.Message, v interface{}) error {
+	data, err := json.Marshal(msg.Payload)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// questionMessage convertit une question en message QUESTION
+func questionMessage(q shared.Question, manche int) shared.Message {
+	return shared.Message{
+		Type: shared.MsgQuestion,
+		Payload: shared.QuestionPayload{
+			Question: shared.QuestionMessage{
+				ID:      q.ID,
+				Text:    q.QuestionText,
+				Options: []string{q.ChoiceA, q.ChoiceB, q.ChoiceC, q.ChoiceD},
+				Level:   q.DifficultyLevel,
+			},
+			Manche: manche,
+		},
+	}
+}
+
+// isCorrectChoice vérifie un choix (0 à 3) par rapport à la lettre attendue
+func isCorrectChoice(q shared.Question, choice int) bool {
+	letters := []string{"A", "B", "C", "D"}
+	if choice < 0 || choice >= len(letters) {
+		return false
+	}
+	return q.CorrectAnswer == letters[choice]
+}
@@ -0,0 +1,329 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"quiz-app-fyne/shared"
+	"sort"
+	"time"
+)
+
+// Pause entre la fin d'une question buzzer et la suivante, le temps d'afficher le gagnant
+const buzzerRevealDelay = 2 * time.Second
+
+// BuzzerRound - Manche buzzer : tous les joueurs reçoivent la même question, seule la
+// première bonne réponse rapporte des points. Une mauvaise réponse coûte une pénalité
+// et exclut le joueur de la question.
+//
+// Les réponses sont départagées selon leur heure d'envoi estimée (arrivée moins la
+// latence du joueur, voir latency.go) : une bonne réponse n'est attribuée qu'après un
+// court délai, pendant lequel une réponse envoyée plus tôt peut encore arriver.
+type BuzzerRound struct {
+	roundBase
+	Questions       []shared.Question
+	TimePerQuestion time.Duration
+	Points          int
+	WrongPenalty    int
+
+	current  int
+	askedAt  time.Time
+	deadline time.Time
+	answered map[int]bool // Joueurs qui ont déjà répondu à la question courante
+	buzzes   []buzz       // Bonnes réponses en attente d'attribution
+	closedAt time.Time    // Fin de la question courante (zéro tant qu'elle est ouverte)
+	lastPing time.Time
+}
+
+// buzz est une bonne réponse reçue pendant le délai d'attribution
+type buzz struct {
+	UserID  int       `json:"user_id"`
+	Arrived time.Time `json:"arrived"`
+	Sent    time.Time `json:"sent"` // Heure d'envoi estimée
+}
+
+func init() {
+	RegisterRound(shared.RoundBuzzer, func() Round { return NewBuzzerRound() })
+}
+
+func NewBuzzerRound() *BuzzerRound {
+	return &BuzzerRound{
+		roundBase:       roundBase{kind: shared.RoundBuzzer, name: "Buzzer"},
+		TimePerQuestion: 10 * time.Second,
+		Points:          20,
+		WrongPenalty:    5,
+	}
+}
+
+func (r *BuzzerRound) Prepare(game *Game) error {
+	questions, err := loadQCMQuestions(game.Settings)
+	if err != nil {
+		return err
+	}
+	r.Questions = questions
+	r.TimePerQuestion = time.Duration(game.Settings.TimePerQuestion) * time.Second
+	return nil
+}
+
+func (r *BuzzerRound) Start(ctx *RoundContext) {
+	r.begin(ctx.Game)
+	r.current = 0
+	r.sendCurrent(ctx)
+}
+
+func (r *BuzzerRound) sendCurrent(ctx *RoundContext) {
+	if r.current >= len(r.Questions) {
+		return
+	}
+	log.Printf("🔔 Question buzzer %d/%d envoyée", r.current+1, len(r.Questions))
+	r.askedAt = time.Now()
+	r.deadline = r.askedAt.Add(r.TimePerQuestion)
+	r.answered = make(map[int]bool)
+	r.buzzes = nil
+	r.closedAt = time.Time{}
+	ctx.Broadcast(questionMessage(r.Questions[r.current], 1))
+	ctx.RecordQCM(r.Questions[r.current])
+	r.ping(ctx, r.askedAt)
+}
+
+// ping mesure la latence des joueurs en lice
+func (r *BuzzerRound) ping(ctx *RoundContext, now time.Time) {
+	r.lastPing = now
+	for _, id := range ctx.ActivePlayers() {
+		ctx.SendTo(id, Latency.Ping(id))
+	}
+}
+
+func (r *BuzzerRound) HandleMessage(ctx *RoundContext, userID int, msg shared.Message) {
+	if msg.Type != shared.MsgAnswer || r.current >= len(r.Questions) || !r.closedAt.IsZero() {
+		return
+	}
+	var payload shared.AnswerPayload
+	if err := decodePayload(msg, &payload); err != nil {
+		return
+	}
+
+	q := r.Questions[r.current]
+	if payload.QuestionID != q.ID || r.answered[userID] {
+		return
+	}
+	r.answered[userID] = true
+	now := time.Now()
+
+	if isCorrectChoice(q, payload.Choice) {
+		r.buzzes = append(r.buzzes, buzz{
+			UserID:  userID,
+			Arrived: now,
+			Sent:    now.Add(-Latency.OneWay(userID)),
+		})
+		return
+	}
+
+	// Mauvaise réponse : pénalité immédiate, le joueur ne peut plus répondre
+	game := ctx.Game
+	game.Scores[userID] -= r.WrongPenalty
+	game.breakStreak(userID)
+	ctx.RecordAnswer(shared.TranscriptAnswer{
+		QuestionID: q.ID,
+		UserID:     userID,
+		Kind:       shared.ActionAnswer,
+		Answer:     choiceLetter(payload.Choice),
+		Points:     -r.WrongPenalty,
+	}, r.askedAt)
+	ctx.Broadcast(shared.Message{
+		Type: shared.MsgBuzzerResult,
+		Payload: shared.BuzzerResultPayload{
+			QuestionID: q.ID,
+			UserID:     userID,
+			Username:   game.Players[userID].Username,
+			Points:     -r.WrongPenalty,
+		},
+	})
+	ctx.BroadcastScores()
+}
+
+func (r *BuzzerRound) Tick(ctx *RoundContext, now time.Time) bool {
+	if r.current >= len(r.Questions) {
+		return true
+	}
+
+	// Question terminée : la suivante après l'annonce du résultat
+	if !r.closedAt.IsZero() {
+		if now.Sub(r.closedAt) < buzzerRevealDelay {
+			return false
+		}
+		r.current++
+		if r.current >= len(r.Questions) {
+			return true
+		}
+		r.sendCurrent(ctx)
+		return false
+	}
+
+	if now.Sub(r.lastPing) >= LatencyPingInterval {
+		r.ping(ctx, now)
+	}
+
+	if len(r.buzzes) > 0 {
+		// Une réponse envoyée plus tôt peut arriver jusqu'à la compensation maximale après la première
+		first := r.buzzes[0].Arrived
+		for _, b := range r.buzzes[1:] {
+			if b.Arrived.Before(first) {
+				first = b.Arrived
+			}
+		}
+		if now.Sub(first) >= MaxLatencyCompensation/2 {
+			r.award(ctx, now)
+		}
+		return false
+	}
+
+	allAnswered := true
+	for _, id := range ctx.ActivePlayers() {
+		if !r.answered[id] {
+			allAnswered = false
+			break
+		}
+	}
+	if allAnswered || !now.Before(r.deadline) {
+		if !allAnswered {
+			log.Printf("⏱️ Temps écoulé pour la question buzzer %d", r.Questions[r.current].ID)
+		}
+		r.close(ctx, now, 0, 0, 0)
+	}
+	return false
+}
+
+// award attribue la question à la bonne réponse envoyée le plus tôt.
+// Les autres bonnes réponses, arrivées trop tard, ne rapportent rien.
+func (r *BuzzerRound) award(ctx *RoundContext, now time.Time) {
+	sort.SliceStable(r.buzzes, func(i, j int) bool {
+		if !r.buzzes[i].Sent.Equal(r.buzzes[j].Sent) {
+			return r.buzzes[i].Sent.Before(r.buzzes[j].Sent)
+		}
+		return r.buzzes[i].Arrived.Before(r.buzzes[j].Arrived)
+	})
+
+	game := ctx.Game
+	q := r.Questions[r.current]
+	winner, won, multiplier := 0, 0, 0.0
+	for _, b := range r.buzzes {
+		// Un joueur parti entre-temps ne peut pas gagner la question
+		if _, ok := game.Players[b.UserID]; !ok {
+			continue
+		}
+		points := 0
+		if winner == 0 {
+			winner = b.UserID
+			points, multiplier = game.scoreCorrect(b.UserID, r.Points)
+			won = points
+			game.Scores[b.UserID] += points
+			log.Printf("🔔 Joueur %d remporte la question %d (+%d points)", b.UserID, q.ID, points)
+		}
+		// Le temps de réponse est mesuré à l'arrivée, pas à l'attribution
+		ctx.RecordAnswer(shared.TranscriptAnswer{
+			QuestionID: q.ID,
+			UserID:     b.UserID,
+			Kind:       shared.ActionAnswer,
+			Answer:     q.CorrectAnswer,
+			Correct:    true,
+			Points:     points,
+		}, r.askedAt.Add(now.Sub(b.Arrived)))
+	}
+	r.close(ctx, now, winner, won, multiplier)
+}
+
+// close termine la question courante et annonce son gagnant (0 si personne) avec ses
+// points et son combo. Les joueurs qui n'ont pas répondu perdent leur série.
+func (r *BuzzerRound) close(ctx *RoundContext, now time.Time, winner, points int, multiplier float64) {
+	q := r.Questions[r.current]
+	r.closedAt = now
+	r.buzzes = nil
+	for _, id := range ctx.ActivePlayers() {
+		if !r.answered[id] {
+			ctx.Game.breakStreak(id)
+		}
+	}
+
+	result := shared.BuzzerResultPayload{
+		QuestionID: q.ID,
+		UserID:     winner,
+		Closed:     true,
+		Answer:     q.CorrectAnswer,
+	}
+	if player, ok := ctx.Game.Players[winner]; ok {
+		result.Username = player.Username
+		result.Correct = true
+		result.Points = points
+		result.Streak = ctx.Game.Streaks[winner]
+		result.Multiplier = multiplier
+	}
+	ctx.Broadcast(shared.Message{Type: shared.MsgBuzzerResult, Payload: result})
+	ctx.BroadcastScores()
+}
+
+func (r *BuzzerRound) Finish(ctx *RoundContext) {
+	r.end(ctx.Game)
+}
+
+// buzzerState est l'état sauvegardé d'une manche buzzer
+type buzzerState struct {
+	baseState
+	Questions       []shared.Question `json:"questions"`
+	TimePerQuestion time.Duration     `json:"time_per_question"`
+	Points          int               `json:"points"`
+	WrongPenalty    int               `json:"wrong_penalty"`
+	Current         int               `json:"current"`
+	AskedAt         time.Time         `json:"asked_at"`
+	Deadline        time.Time         `json:"deadline"`
+	Answered        map[int]bool      `json:"answered"`
+	Buzzes          []buzz            `json:"buzzes"`
+	ClosedAt        time.Time         `json:"closed_at"`
+}
+
+func (r *BuzzerRound) SaveState() (json.RawMessage, error) {
+	return json.Marshal(buzzerState{
+		baseState:       r.saveBase(),
+		Questions:       r.Questions,
+		TimePerQuestion: r.TimePerQuestion,
+		Points:          r.Points,
+		WrongPenalty:    r.WrongPenalty,
+		Current:         r.current,
+		AskedAt:         r.askedAt,
+		Deadline:        r.deadline,
+		Answered:        r.answered,
+		Buzzes:          r.buzzes,
+		ClosedAt:        r.closedAt,
+	})
+}
+
+func (r *BuzzerRound) LoadState(data json.RawMessage, offset time.Duration) error {
+	var s buzzerState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	r.loadBase(s.baseState)
+	r.Questions = s.Questions
+	r.TimePerQuestion = s.TimePerQuestion
+	r.Points = s.Points
+	r.WrongPenalty = s.WrongPenalty
+	r.current = s.Current
+	r.askedAt = shiftTime(s.AskedAt, offset)
+	r.deadline = shiftTime(s.Deadline, offset)
+	r.answered = s.Answered
+	if r.answered == nil {
+		r.answered = make(map[int]bool)
+	}
+	r.buzzes = s.Buzzes
+	for i := range r.buzzes {
+		r.buzzes[i].Arrived = shiftTime(r.buzzes[i].Arrived, offset)
+		r.buzzes[i].Sent = shiftTime(r.buzzes[i].Sent, offset)
+	}
+	r.closedAt = shiftTime(s.ClosedAt, offset)
+	return nil
+}
+
+func (r *BuzzerRound) Resend(ctx *RoundContext, userID int) {
+	if r.current < len(r.Questions) && r.closedAt.IsZero() && !r.answered[userID] {
+		ctx.SendTo(userID, questionMessage(r.Questions[r.current], 1))
+	}
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"quiz-app-fyne/shared"
+	"testing"
+	"time"
+)
+
+func buzzerAnswer(userID, questionID, choice int) shared.Message {
+	return shared.Message{
+		Type:    shared.MsgAnswer,
+		Payload: shared.AnswerPayload{UserID: userID, QuestionID: questionID, Choice: choice},
+	}
+}
+
+// La bonne réponse arrivée en second l'emporte si elle a été envoyée plus tôt
+func TestBuzzerOrdersBySendTime(t *testing.T) {
+	useTestDB(t)
+	game := newTestGame(t, shared.DefaultGameSettings(), 1, 2, 3)
+
+	previous := Latency
+	Latency = &latencyTracker{
+		pending: make(map[int]pendingPing),
+		rtt:     map[int]time.Duration{1: 0, 2: 200 * time.Millisecond},
+	}
+	t.Cleanup(func() { Latency = previous })
+
+	round := NewBuzzerRound()
+	round.Questions = []shared.Question{{ID: 7, QuestionText: "Capitale de l'Italie ?", CorrectAnswer: "B"}}
+	game.Rounds = []Round{round}
+	game.CurrentRound = 0
+	ctx := &RoundContext{Game: game}
+	round.Start(ctx)
+
+	round.HandleMessage(ctx, 3, buzzerAnswer(3, 7, 0))
+	if game.Scores[3] != -round.WrongPenalty {
+		t.Errorf("mauvaise réponse : score %d, attendu %d", game.Scores[3], -round.WrongPenalty)
+	}
+	round.HandleMessage(ctx, 1, buzzerAnswer(1, 7, 1))
+	round.HandleMessage(ctx, 2, buzzerAnswer(2, 7, 1))
+
+	// Pendant la compensation, la question n'est pas encore attribuée
+	arrived := round.buzzes[0].Arrived
+	round.Tick(ctx, arrived.Add(MaxLatencyCompensation/4))
+	if game.Scores[1] != 0 || game.Scores[2] != 0 {
+		t.Fatalf("question attribuée avant la fin de la compensation : %v", game.Scores)
+	}
+
+	round.Tick(ctx, arrived.Add(MaxLatencyCompensation/2))
+	if game.Scores[2] != round.Points || game.Scores[1] != 0 {
+		t.Errorf("scores %v, attendu %d points pour le joueur 2 seul", game.Scores, round.Points)
+	}
+	if round.closedAt.IsZero() {
+		t.Error("question toujours ouverte après l'attribution")
+	}
+}
@@ -0,0 +1,344 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"quiz-app-fyne/shared"
+	"time"
+)
+
+// QCMRound - Manche 1 : tous les joueurs reçoivent la même question en même temps
+type QCMRound struct {
+	roundBase
+	Questions       []shared.Question
+	TimePerQuestion time.Duration
+	Points          int
+
+	current  int
+	deadline time.Time
+	askedAt  time.Time
+	answered map[int]bool
+	// Jokers joués sur la question courante (voir powerups.go)
+	removed  map[int][]int // Choix retirés par le 50/50
+	doubled  map[int]bool  // Points doublés
+	extended map[int]bool  // Temps supplémentaire
+}
+
+func init() {
+	RegisterRound(shared.RoundQCM, func() Round { return NewQCMRound() })
+}
+
+func NewQCMRound() *QCMRound {
+	return &QCMRound{
+		roundBase:       roundBase{kind: shared.RoundQCM, name: "QCM"},
+		TimePerQuestion: 10 * time.Second,
+		Points:          15,
+	}
+}
+
+func (r *QCMRound) Prepare(game *Game) error {
+	questions, err := loadQCMQuestions(game.Settings)
+	if err != nil {
+		return err
+	}
+	r.Questions = questions
+	r.TimePerQuestion = time.Duration(game.Settings.TimePerQuestion) * time.Second
+	return nil
+}
+
+// loadQCMQuestions tire les questions d'une manche à questions communes
+// selon le nombre, la difficulté et les catégories choisis par l'hôte
+func loadQCMQuestions(settings shared.GameSettings) ([]shared.Question, error) {
+	questions, err := loadQuestions(settings, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(questions) == 0 {
+		return nil, fmt.Errorf("aucune question QCM disponible")
+	}
+	return questions, nil
+}
+
+func (r *QCMRound) Start(ctx *RoundContext) {
+	r.begin(ctx.Game)
+	r.current = 0
+	r.sendCurrent(ctx)
+}
+
+func (r *QCMRound) sendCurrent(ctx *RoundContext) {
+	if r.current >= len(r.Questions) {
+		return
+	}
+	log.Printf("📝 Question %d/%d envoyée", r.current+1, len(r.Questions))
+	r.askedAt = time.Now()
+	r.deadline = r.askedAt.Add(r.TimePerQuestion)
+	r.answered = make(map[int]bool)
+	r.removed = make(map[int][]int)
+	r.doubled = make(map[int]bool)
+	r.extended = make(map[int]bool)
+	for id := range ctx.Game.Players {
+		ctx.SendTo(id, r.questionFor(ctx.Game, id))
+	}
+	ctx.RecordQCM(r.Questions[r.current])
+}
+
+// questionFor prépare la question courante pour un joueur, avec ses jokers restants
+func (r *QCMRound) questionFor(game *Game, userID int) shared.Message {
+	msg := questionMessage(r.Questions[r.current], 1)
+	if game.isActive(userID) {
+		payload := msg.Payload.(shared.QuestionPayload)
+		payload.PowerUps = game.powerUpInventory(userID)
+		msg.Payload = payload
+	}
+	return msg
+}
+
+// deadlineFor renvoie l'heure limite de réponse d'un joueur, temps supplémentaire compris
+func (r *QCMRound) deadlineFor(userID int) time.Time {
+	if r.extended[userID] {
+		return r.deadline.Add(ExtraTimeBonus)
+	}
+	return r.deadline
+}
+
+func (r *QCMRound) HandleMessage(ctx *RoundContext, userID int, msg shared.Message) {
+	if r.current >= len(r.Questions) {
+		return
+	}
+	switch msg.Type {
+	case shared.MsgAnswer:
+		var payload shared.AnswerPayload
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		r.answer(ctx, userID, payload)
+
+	case shared.MsgUsePowerUp:
+		var payload shared.UsePowerUpPayload
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		r.usePowerUp(ctx, userID, payload)
+	}
+}
+
+func (r *QCMRound) answer(ctx *RoundContext, userID int, payload shared.AnswerPayload) {
+	q := r.Questions[r.current]
+	if payload.QuestionID != q.ID || r.answered[userID] || !time.Now().Before(r.deadlineFor(userID)) {
+		return
+	}
+	r.answered[userID] = true
+
+	game := ctx.Game
+	correct := isCorrectChoice(q, payload.Choice)
+	points := 0
+	multiplier := 1.0
+	if correct {
+		points, multiplier = game.scoreCorrect(userID, r.Points)
+		if r.doubled[userID] {
+			points *= 2
+		}
+		game.Scores[userID] += points
+		log.Printf("✅ Joueur %d: +%d points (manche 1, série %d)", userID, points, game.Streaks[userID])
+	} else {
+		game.breakStreak(userID)
+	}
+	ctx.RecordAnswer(shared.TranscriptAnswer{
+		QuestionID: q.ID,
+		UserID:     userID,
+		Kind:       shared.ActionAnswer,
+		Answer:     choiceLetter(payload.Choice),
+		Correct:    correct,
+		Points:     points,
+	}, r.askedAt)
+	ctx.SendTo(userID, game.answerResult(userID, q.ID, correct, points, multiplier))
+}
+
+// usePowerUp applique un joker sur la question courante : un joker de chaque type
+// au plus par question, avant d'y avoir répondu
+func (r *QCMRound) usePowerUp(ctx *RoundContext, userID int, payload shared.UsePowerUpPayload) {
+	q := r.Questions[r.current]
+	if payload.QuestionID != q.ID {
+		return
+	}
+	if r.answered[userID] || !time.Now().Before(r.deadlineFor(userID)) {
+		ctx.SendError(userID, "Trop tard pour jouer un joker sur cette question")
+		return
+	}
+	if r.powerUpUsed(userID, payload.PowerUp) {
+		ctx.SendError(userID, "Joker déjà joué sur cette question")
+		return
+	}
+
+	game := ctx.Game
+	if err := game.consumePowerUp(userID, payload.PowerUp); err != nil {
+		ctx.SendError(userID, err.Error())
+		return
+	}
+	switch payload.PowerUp {
+	case shared.PowerUpFiftyFifty:
+		r.removed[userID] = fiftyFifty(q)
+	case shared.PowerUpDouble:
+		r.doubled[userID] = true
+	case shared.PowerUpExtraTime:
+		r.extended[userID] = true
+	case shared.PowerUpSkip:
+		// Question passée : ni points ni série cassée
+		r.answered[userID] = true
+	}
+	log.Printf("🃏 Joueur %d joue le joker %s (question %d)", userID, payload.PowerUp, q.ID)
+	ctx.RecordAnswer(shared.TranscriptAnswer{
+		QuestionID: q.ID,
+		UserID:     userID,
+		Kind:       shared.ActionPowerUp,
+		Answer:     payload.PowerUp,
+	}, r.askedAt)
+	ctx.SendTo(userID, r.powerUpResult(game, userID, payload.PowerUp))
+}
+
+// powerUpUsed indique si un joueur a déjà joué ce joker sur la question courante
+func (r *QCMRound) powerUpUsed(userID int, kind string) bool {
+	switch kind {
+	case shared.PowerUpFiftyFifty:
+		return r.removed[userID] != nil
+	case shared.PowerUpDouble:
+		return r.doubled[userID]
+	case shared.PowerUpExtraTime:
+		return r.extended[userID]
+	}
+	return false
+}
+
+// powerUpResult décrit l'effet d'un joker joué sur la question courante
+func (r *QCMRound) powerUpResult(game *Game, userID int, kind string) shared.Message {
+	result := shared.PowerUpResultPayload{
+		QuestionID: r.Questions[r.current].ID,
+		PowerUp:    kind,
+		Inventory:  game.powerUpInventory(userID),
+	}
+	switch kind {
+	case shared.PowerUpFiftyFifty:
+		result.Removed = r.removed[userID]
+	case shared.PowerUpExtraTime:
+		result.ExtraSeconds = int(ExtraTimeBonus / time.Second)
+	}
+	return shared.Message{Type: shared.MsgPowerUpResult, Payload: result}
+}
+
+func (r *QCMRound) Tick(ctx *RoundContext, now time.Time) bool {
+	if r.current >= len(r.Questions) {
+		return true
+	}
+
+	// La question reste ouverte tant qu'un joueur peut encore répondre
+	allAnswered := true
+	open := false
+	for _, id := range ctx.ActivePlayers() {
+		if !r.answered[id] {
+			allAnswered = false
+			if now.Before(r.deadlineFor(id)) {
+				open = true
+			}
+		}
+	}
+	if !allAnswered && open {
+		return false
+	}
+	if !allAnswered {
+		log.Printf("⏱️ Temps écoulé pour la question %d", r.Questions[r.current].ID)
+		// Une question sans réponse casse la série
+		for _, id := range ctx.ActivePlayers() {
+			if !r.answered[id] {
+				ctx.Game.breakStreak(id)
+			}
+		}
+	}
+
+	ctx.BroadcastScores()
+	r.current++
+	if r.current >= len(r.Questions) {
+		return true
+	}
+	r.sendCurrent(ctx)
+	return false
+}
+
+func (r *QCMRound) Finish(ctx *RoundContext) {
+	r.end(ctx.Game)
+}
+
+// qcmState est l'état sauvegardé d'une manche QCM
+type qcmState struct {
+	baseState
+	Questions       []shared.Question `json:"questions"`
+	TimePerQuestion time.Duration     `json:"time_per_question"`
+	Points          int               `json:"points"`
+	Current         int               `json:"current"`
+	Deadline        time.Time         `json:"deadline"`
+	AskedAt         time.Time         `json:"asked_at"`
+	Answered        map[int]bool      `json:"answered"`
+	Removed         map[int][]int     `json:"removed"`
+	Doubled         map[int]bool      `json:"doubled"`
+	Extended        map[int]bool      `json:"extended"`
+}
+
+func (r *QCMRound) SaveState() (json.RawMessage, error) {
+	return json.Marshal(qcmState{
+		baseState:       r.saveBase(),
+		Questions:       r.Questions,
+		TimePerQuestion: r.TimePerQuestion,
+		Points:          r.Points,
+		Current:         r.current,
+		Deadline:        r.deadline,
+		AskedAt:         r.askedAt,
+		Answered:        r.answered,
+		Removed:         r.removed,
+		Doubled:         r.doubled,
+		Extended:        r.extended,
+	})
+}
+
+func (r *QCMRound) LoadState(data json.RawMessage, offset time.Duration) error {
+	var s qcmState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	r.loadBase(s.baseState)
+	r.Questions = s.Questions
+	r.TimePerQuestion = s.TimePerQuestion
+	r.Points = s.Points
+	r.current = s.Current
+	r.deadline = shiftTime(s.Deadline, offset)
+	r.askedAt = shiftTime(s.AskedAt, offset)
+	r.answered = s.Answered
+	if r.answered == nil {
+		r.answered = make(map[int]bool)
+	}
+	r.removed = s.Removed
+	if r.removed == nil {
+		r.removed = make(map[int][]int)
+	}
+	r.doubled = s.Doubled
+	if r.doubled == nil {
+		r.doubled = make(map[int]bool)
+	}
+	r.extended = s.Extended
+	if r.extended == nil {
+		r.extended = make(map[int]bool)
+	}
+	return nil
+}
+
+func (r *QCMRound) Resend(ctx *RoundContext, userID int) {
+	if r.current >= len(r.Questions) || r.answered[userID] {
+		return
+	}
+	ctx.SendTo(userID, r.questionFor(ctx.Game, userID))
+	// Les jokers déjà joués sur la question restent actifs
+	for _, kind := range shared.PowerUpKinds {
+		if r.powerUpUsed(userID, kind) {
+			ctx.SendTo(userID, r.powerUpResult(ctx.Game, userID, kind))
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"quiz-app-fyne/shared"
+	"strconv"
+	"time"
+)
+
+// RiddleRound - Manche 3 : devinette avec indices payants
+type RiddleRound struct {
+	roundBase
+	Riddle    *shared.Riddle
+	Duration  time.Duration
+	Points    int
+	HintCosts []int // Coût des indices 1 et 2
+	Tolerance int   // Fautes de frappe tolérées
+	Attempts  int   // Essais par joueur
+
+	deadline  time.Time
+	startedAt time.Time
+	attempts  map[int]int  // Essais utilisés par joueur
+	solved    map[int]bool // Joueurs ayant trouvé la réponse
+	hints     map[int]int  // Dernier indice acheté par joueur
+}
+
+func init() {
+	RegisterRound(shared.RoundRiddle, func() Round { return NewRiddleRound() })
+}
+
+func NewRiddleRound() *RiddleRound {
+	return &RiddleRound{
+		roundBase: roundBase{kind: shared.RoundRiddle, name: "Devinette"},
+		Duration:  60 * time.Second,
+		Points:    100,
+		HintCosts: []int{25, 50},
+		Tolerance: 1,
+		Attempts:  3,
+	}
+}
+
+func (r *RiddleRound) Prepare(game *Game) error {
+	riddle, err := DB.GetRandomRiddle()
+	if err != nil {
+		return err
+	}
+	r.Riddle = riddle
+	if len(game.Settings.HintCosts) == 2 {
+		r.HintCosts = game.Settings.HintCosts
+	}
+	r.Tolerance = game.Settings.TypoTolerance
+	if game.Settings.RiddleAttempts > 0 {
+		r.Attempts = game.Settings.RiddleAttempts
+	}
+	return nil
+}
+
+func (r *RiddleRound) Start(ctx *RoundContext) {
+	r.begin(ctx.Game)
+	r.startedAt = time.Now()
+	r.deadline = r.startedAt.Add(r.Duration)
+	r.attempts = make(map[int]int)
+	r.solved = make(map[int]bool)
+	r.hints = make(map[int]int)
+	ctx.Broadcast(shared.Message{
+		Type: shared.MsgRiddle,
+		Payload: shared.RiddlePayload{
+			RiddleID: r.Riddle.ID,
+			Text:     r.Riddle.RiddleText,
+		},
+	})
+	ctx.RecordQuestion(r.Riddle.ID, r.Riddle.RiddleText, nil, r.Riddle.CorrectWord)
+}
+
+func (r *RiddleRound) HandleMessage(ctx *RoundContext, userID int, msg shared.Message) {
+	switch msg.Type {
+	case shared.MsgRequestRiddleHint:
+		var payload shared.RiddleHintRequestPayload
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		r.sendHint(ctx, userID, payload.HintType)
+
+	case shared.MsgRiddleAnswer:
+		var payload shared.RiddleAnswerPayload
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		r.checkAnswer(ctx, userID, payload.Answer)
+	}
+}
+
+// checkAnswer compte l'essai du joueur et lui renvoie le verdict
+func (r *RiddleRound) checkAnswer(ctx *RoundContext, userID int, answer string) {
+	result := shared.RiddleLocked
+	points := 0
+	if !r.done(userID) {
+		r.attempts[userID]++
+		result = matchRiddleAnswer(answer, r.Riddle.AcceptedAnswers, r.Tolerance)
+		if result == shared.RiddleCorrect {
+			r.solved[userID] = true
+			points = r.Points
+			ctx.Game.Scores[userID] += points
+			log.Printf("🎉 Joueur %d a deviné correctement ! +%d points", userID, points)
+		}
+		ctx.RecordAnswer(shared.TranscriptAnswer{
+			QuestionID: r.Riddle.ID,
+			UserID:     userID,
+			Kind:       shared.ActionAnswer,
+			Answer:     answer,
+			Correct:    result == shared.RiddleCorrect,
+			Points:     points,
+		}, r.startedAt)
+	}
+
+	ctx.SendTo(userID, shared.Message{
+		Type: shared.MsgRiddleResult,
+		Payload: shared.RiddleResultPayload{
+			RiddleID:     r.Riddle.ID,
+			Result:       result,
+			Points:       points,
+			AttemptsLeft: r.Attempts - r.attempts[userID],
+			Solved:       r.solved[userID],
+		},
+	})
+	if result != shared.RiddleLocked {
+		ctx.BroadcastScores()
+	}
+}
+
+// done indique si le joueur a trouvé la réponse ou épuisé ses essais
+func (r *RiddleRound) done(userID int) bool {
+	return r.solved[userID] || r.attempts[userID] >= r.Attempts
+}
+
+// sendHint envoie un indice : les indices s'achètent dans l'ordre et
+// un indice déjà acheté est renvoyé gratuitement
+func (r *RiddleRound) sendHint(ctx *RoundContext, userID, level int) {
+	var text string
+	if level == 1 {
+		text = r.Riddle.HintLevel1
+	} else if level == 2 {
+		text = r.Riddle.HintLevel2
+	} else {
+		return
+	}
+
+	game := ctx.Game
+	cost := 0
+	if level > r.hints[userID] {
+		if level != r.hints[userID]+1 {
+			ctx.SendError(userID, fmt.Sprintf("Achète d'abord l'indice %d", r.hints[userID]+1))
+			return
+		}
+		if r.done(userID) {
+			ctx.SendError(userID, "Tu ne peux plus répondre à cette devinette")
+			return
+		}
+		cost = r.HintCosts[level-1]
+		budget := game.Settings.HintBudget
+		if budget > 0 && game.HintSpent[userID]+cost > budget {
+			ctx.SendError(userID, fmt.Sprintf("Budget d'indices épuisé (%d pts)", budget))
+			return
+		}
+		if game.Scores[userID] < cost {
+			ctx.SendError(userID, fmt.Sprintf("Pas assez de points (%d requis)", cost))
+			return
+		}
+		game.Scores[userID] -= cost
+		game.HintSpent[userID] += cost
+		r.hints[userID] = level
+		log.Printf("💡 Joueur %d achète l'indice %d (-%d points)", userID, level, cost)
+		ctx.RecordAnswer(shared.TranscriptAnswer{
+			QuestionID: r.Riddle.ID,
+			UserID:     userID,
+			Kind:       shared.ActionHint,
+			Answer:     strconv.Itoa(level),
+			Points:     -cost,
+		}, r.startedAt)
+	}
+
+	ctx.SendTo(userID, shared.Message{
+		Type: shared.MsgRiddleHint,
+		Payload: shared.RiddleHintPayload{
+			RiddleID: r.Riddle.ID,
+			Level:    level,
+			Text:     text,
+			Cost:     cost,
+		},
+	})
+	if cost > 0 {
+		ctx.BroadcastScores()
+	}
+}
+
+func (r *RiddleRound) Tick(ctx *RoundContext, now time.Time) bool {
+	if !now.Before(r.deadline) {
+		return true
+	}
+	// Fin anticipée quand plus personne ne peut répondre
+	for _, id := range ctx.ActivePlayers() {
+		if !r.done(id) {
+			return false
+		}
+	}
+	log.Printf("✅ Partie %s - tous les joueurs ont terminé la devinette", ctx.Game.Code)
+	return true
+}
+
+func (r *RiddleRound) Finish(ctx *RoundContext) {
+	r.end(ctx.Game)
+}
+
+// riddleState est l'état sauvegardé d'une manche devinette
+type riddleState struct {
+	baseState
+	Riddle    *shared.Riddle `json:"riddle"`
+	Duration  time.Duration  `json:"duration"`
+	Points    int            `json:"points"`
+	HintCosts []int          `json:"hint_costs"`
+	Tolerance int            `json:"tolerance"`
+	Attempts  int            `json:"attempts"`
+	Deadline  time.Time      `json:"deadline"`
+	StartedAt time.Time      `json:"started_at"`
+	Used      map[int]int    `json:"used"`
+	Solved    map[int]bool   `json:"solved"`
+	Hints     map[int]int    `json:"hints"`
+}
+
+func (r *RiddleRound) SaveState() (json.RawMessage, error) {
+	return json.Marshal(riddleState{
+		baseState: r.saveBase(),
+		Riddle:    r.Riddle,
+		Duration:  r.Duration,
+		Points:    r.Points,
+		HintCosts: r.HintCosts,
+		Tolerance: r.Tolerance,
+		Attempts:  r.Attempts,
+		Deadline:  r.deadline,
+		StartedAt: r.startedAt,
+		Used:      r.attempts,
+		Solved:    r.solved,
+		Hints:     r.hints,
+	})
+}
+
+func (r *RiddleRound) LoadState(data json.RawMessage, offset time.Duration) error {
+	var s riddleState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	r.loadBase(s.baseState)
+	r.Riddle = s.Riddle
+	r.Duration = s.Duration
+	r.Points = s.Points
+	r.HintCosts = s.HintCosts
+	r.Tolerance = s.Tolerance
+	r.Attempts = s.Attempts
+	r.deadline = shiftTime(s.Deadline, offset)
+	r.startedAt = shiftTime(s.StartedAt, offset)
+	r.attempts = s.Used
+	r.solved = s.Solved
+	r.hints = s.Hints
+	return nil
+}
+
+// Resend renvoie la devinette et les indices déjà achetés
+func (r *RiddleRound) Resend(ctx *RoundContext, userID int) {
+	ctx.SendTo(userID, shared.Message{
+		Type: shared.MsgRiddle,
+		Payload: shared.RiddlePayload{
+			RiddleID: r.Riddle.ID,
+			Text:     r.Riddle.RiddleText,
+		},
+	})
+	for level := 1; level <= r.hints[userID]; level++ {
+		r.sendHint(ctx, userID, level)
+	}
+}
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"quiz-app-fyne/shared"
+	"testing"
+	"time"
+)
+
+// startRiddle lance une manche devinette sur la réponse « éléphant »
+func startRiddle(t *testing.T, players ...int) (*RiddleRound, *RoundContext) {
+	t.Helper()
+	useTestDB(t)
+	game := newTestGame(t, shared.DefaultGameSettings(), players...)
+	round := NewRiddleRound()
+	round.Riddle = &shared.Riddle{
+		ID:              1,
+		RiddleText:      "Je suis gris et j'ai une trompe",
+		CorrectWord:     "éléphant",
+		HintLevel1:      "Il vit en Afrique",
+		HintLevel2:      "Il a de grandes oreilles",
+		AcceptedAnswers: []string{"éléphant"},
+	}
+	round.Attempts = 2
+	game.Rounds = []Round{round}
+	game.CurrentRound = 0
+	ctx := &RoundContext{Game: game}
+	round.Start(ctx)
+	return round, ctx
+}
+
+func riddleAnswer(userID int, answer string) shared.Message {
+	return shared.Message{
+		Type:    shared.MsgRiddleAnswer,
+		Payload: shared.RiddleAnswerPayload{UserID: userID, Answer: answer},
+	}
+}
+
+func TestRiddleAttemptsLimit(t *testing.T) {
+	round, ctx := startRiddle(t, 1, 2)
+
+	round.HandleMessage(ctx, 1, riddleAnswer(1, "girafe"))
+	round.HandleMessage(ctx, 1, riddleAnswer(1, "zèbre"))
+	if !round.done(1) {
+		t.Fatalf("joueur 1 : %d essais utilisés sur %d, attendu la fin de ses essais", round.attempts[1], round.Attempts)
+	}
+
+	// Une bonne réponse après le dernier essai est ignorée
+	round.HandleMessage(ctx, 1, riddleAnswer(1, "éléphant"))
+	if round.attempts[1] != 2 || round.solved[1] || ctx.Game.Scores[1] != 0 {
+		t.Errorf("joueur 1 : essais %d, trouvé %v, score %d après ses essais, attendu 2, false, 0",
+			round.attempts[1], round.solved[1], ctx.Game.Scores[1])
+	}
+
+	// La manche continue tant qu'un joueur peut répondre
+	if round.Tick(ctx, time.Now()) {
+		t.Fatal("manche terminée alors que le joueur 2 n'a pas répondu")
+	}
+	round.HandleMessage(ctx, 2, riddleAnswer(2, "elephant"))
+	if !round.solved[2] || ctx.Game.Scores[2] != round.Points {
+		t.Errorf("joueur 2 : trouvé %v, score %d, attendu true, %d", round.solved[2], ctx.Game.Scores[2], round.Points)
+	}
+
+	// Une fois trouvée, la réponse ne rapporte plus rien
+	round.HandleMessage(ctx, 2, riddleAnswer(2, "éléphant"))
+	if round.attempts[2] != 1 || ctx.Game.Scores[2] != round.Points {
+		t.Errorf("joueur 2 : essais %d, score %d après avoir trouvé, attendu 1, %d", round.attempts[2], ctx.Game.Scores[2], round.Points)
+	}
+	if !round.Tick(ctx, time.Now()) {
+		t.Error("manche toujours en cours alors que plus personne ne peut répondre")
+	}
+}
+
+func TestRiddleHintOrder(t *testing.T) {
+	round, ctx := startRiddle(t, 1)
+	game := ctx.Game
+	game.Scores[1] = 100
+
+	round.sendHint(ctx, 1, 2)
+	if round.hints[1] != 0 || game.Scores[1] != 100 {
+		t.Fatalf("indice 2 acheté avant l'indice 1 (indice %d, score %d)", round.hints[1], game.Scores[1])
+	}
+
+	round.sendHint(ctx, 1, 1)
+	round.sendHint(ctx, 1, 2)
+	if round.hints[1] != 2 || game.Scores[1] != 25 || game.HintSpent[1] != 75 {
+		t.Fatalf("après les deux indices : indice %d, score %d, dépensé %d, attendu 2, 25, 75",
+			round.hints[1], game.Scores[1], game.HintSpent[1])
+	}
+
+	// Un indice déjà acheté est renvoyé gratuitement
+	round.sendHint(ctx, 1, 1)
+	round.sendHint(ctx, 1, 2)
+	if game.Scores[1] != 25 || game.HintSpent[1] != 75 {
+		t.Errorf("indices renvoyés payants : score %d, dépensé %d", game.Scores[1], game.HintSpent[1])
+	}
+}
+
+func TestRiddleHintRefusals(t *testing.T) {
+	t.Run("points insuffisants", func(t *testing.T) {
+		round, ctx := startRiddle(t, 1)
+		ctx.Game.Scores[1] = 20
+
+		round.sendHint(ctx, 1, 1)
+		if round.hints[1] != 0 || ctx.Game.Scores[1] != 20 {
+			t.Errorf("indice acheté sans assez de points (indice %d, score %d)", round.hints[1], ctx.Game.Scores[1])
+		}
+	})
+
+	t.Run("budget épuisé", func(t *testing.T) {
+		round, ctx := startRiddle(t, 1)
+		ctx.Game.Settings.HintBudget = 50
+		ctx.Game.Scores[1] = 100
+
+		round.sendHint(ctx, 1, 1)
+		round.sendHint(ctx, 1, 2)
+		if round.hints[1] != 1 || ctx.Game.HintSpent[1] != 25 {
+			t.Errorf("budget de 50 : indice %d, dépensé %d, attendu 1, 25", round.hints[1], ctx.Game.HintSpent[1])
+		}
+	})
+
+	t.Run("essais épuisés", func(t *testing.T) {
+		round, ctx := startRiddle(t, 1)
+		ctx.Game.Scores[1] = 100
+		round.HandleMessage(ctx, 1, riddleAnswer(1, "girafe"))
+		round.HandleMessage(ctx, 1, riddleAnswer(1, "zèbre"))
+
+		round.sendHint(ctx, 1, 1)
+		if round.hints[1] != 0 || ctx.Game.Scores[1] != 100 {
+			t.Errorf("indice acheté après les essais (indice %d, score %d)", round.hints[1], ctx.Game.Scores[1])
+		}
+	})
+}
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"quiz-app-fyne/shared"
+	"time"
+)
+
+// TimeAttackRound - Manche 2 : course contre la montre, chaque joueur avance à son rythme
+type TimeAttackRound struct {
+	roundBase
+	Questions    []shared.Question
+	Duration     time.Duration
+	Points       int
+	WrongPenalty int
+
+	deadline time.Time
+	index    map[int]int       // Question courante de chaque joueur
+	sentAt   map[int]time.Time // Envoi de la question courante de chaque joueur
+}
+
+func init() {
+	RegisterRound(shared.RoundTimeAttack, func() Round { return NewTimeAttackRound() })
+}
+
+func NewTimeAttackRound() *TimeAttackRound {
+	return &TimeAttackRound{
+		roundBase:    roundBase{kind: shared.RoundTimeAttack, name: "Contre-la-montre"},
+		Duration:     60 * time.Second,
+		Points:       10,
+		WrongPenalty: 3,
+	}
+}
+
+func (r *TimeAttackRound) Prepare(game *Game) error {
+	questions, err := loadQuestions(game.Settings, 2)
+	if err != nil {
+		return err
+	}
+	if len(questions) == 0 {
+		return fmt.Errorf("aucune question contre-la-montre disponible")
+	}
+	r.Questions = questions
+	return nil
+}
+
+func (r *TimeAttackRound) Start(ctx *RoundContext) {
+	r.begin(ctx.Game)
+	r.deadline = time.Now().Add(r.Duration)
+	r.index = make(map[int]int)
+	r.sentAt = make(map[int]time.Time)
+	for _, id := range ctx.ActivePlayers() {
+		r.index[id] = 0
+		r.sendNext(ctx, id)
+	}
+}
+
+// sendNext envoie au joueur sa prochaine question
+func (r *TimeAttackRound) sendNext(ctx *RoundContext, userID int) {
+	index := r.index[userID]
+	if index >= len(r.Questions) {
+		return
+	}
+	r.sentAt[userID] = time.Now()
+	ctx.SendTo(userID, questionMessage(r.Questions[index], 2))
+	ctx.RecordQCM(r.Questions[index])
+}
+
+func (r *TimeAttackRound) HandleMessage(ctx *RoundContext, userID int, msg shared.Message) {
+	if msg.Type != shared.MsgAnswer || time.Now().After(r.deadline) {
+		return
+	}
+	var payload shared.AnswerPayload
+	if err := decodePayload(msg, &payload); err != nil {
+		return
+	}
+
+	index, ok := r.index[userID]
+	if !ok || index >= len(r.Questions) || r.Questions[index].ID != payload.QuestionID {
+		return
+	}
+
+	game := ctx.Game
+	correct := isCorrectChoice(r.Questions[index], payload.Choice)
+	points := -r.WrongPenalty
+	multiplier := 1.0
+	if correct {
+		points, multiplier = game.scoreCorrect(userID, r.Points)
+	} else {
+		game.breakStreak(userID)
+	}
+	game.Scores[userID] += points
+	ctx.RecordAnswer(shared.TranscriptAnswer{
+		QuestionID: payload.QuestionID,
+		UserID:     userID,
+		Kind:       shared.ActionAnswer,
+		Answer:     choiceLetter(payload.Choice),
+		Correct:    correct,
+		Points:     points,
+	}, r.sentAt[userID])
+	ctx.SendTo(userID, game.answerResult(userID, payload.QuestionID, correct, points, multiplier))
+
+	// avancer l'index et envoyer la prochaine question
+	r.index[userID]++
+	r.sendNext(ctx, userID)
+}
+
+func (r *TimeAttackRound) Tick(ctx *RoundContext, now time.Time) bool {
+	if !now.Before(r.deadline) {
+		log.Printf("⏱️ Fin Manche 2")
+		return true
+	}
+	for _, id := range ctx.ActivePlayers() {
+		if r.index[id] < len(r.Questions) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *TimeAttackRound) Finish(ctx *RoundContext) {
+	r.end(ctx.Game)
+	ctx.BroadcastScores()
+}
+
+// timeAttackState est l'état sauvegardé d'une manche contre-la-montre
+type timeAttackState struct {
+	baseState
+	Questions    []shared.Question `json:"questions"`
+	Duration     time.Duration     `json:"duration"`
+	Points       int               `json:"points"`
+	WrongPenalty int               `json:"wrong_penalty"`
+	Deadline     time.Time         `json:"deadline"`
+	Index        map[int]int       `json:"index"`
+	SentAt       map[int]time.Time `json:"sent_at"`
+}
+
+func (r *TimeAttackRound) SaveState() (json.RawMessage, error) {
+	return json.Marshal(timeAttackState{
+		baseState:    r.saveBase(),
+		Questions:    r.Questions,
+		Duration:     r.Duration,
+		Points:       r.Points,
+		WrongPenalty: r.WrongPenalty,
+		Deadline:     r.deadline,
+		Index:        r.index,
+		SentAt:       r.sentAt,
+	})
+}
+
+func (r *TimeAttackRound) LoadState(data json.RawMessage, offset time.Duration) error {
+	var s timeAttackState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	r.loadBase(s.baseState)
+	r.Questions = s.Questions
+	r.Duration = s.Duration
+	r.Points = s.Points
+	r.WrongPenalty = s.WrongPenalty
+	r.deadline = shiftTime(s.Deadline, offset)
+	r.index = s.Index
+	r.sentAt = make(map[int]time.Time, len(s.SentAt))
+	for id, at := range s.SentAt {
+		r.sentAt[id] = shiftTime(at, offset)
+	}
+	return nil
+}
+
+func (r *TimeAttackRound) Resend(ctx *RoundContext, userID int) {
+	if index, ok := r.index[userID]; ok && index < len(r.Questions) {
+		ctx.SendTo(userID, questionMessage(r.Questions[index], 2))
+	}
+}
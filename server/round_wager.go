@@ -0,0 +1,346 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"quiz-app-fyne/shared"
+	"sort"
+	"time"
+)
+
+// Phases d'une manche à mise
+const (
+	wagerPhaseBet      = "bet"      // Les joueurs misent en secret
+	wagerPhaseQuestion = "question" // La question est posée
+	wagerPhaseReveal   = "reveal"   // Les mises sont révélées une à une
+)
+
+// Pause entre deux révélations de mise, puis après la dernière
+const (
+	wagerRevealStep  = 1500 * time.Millisecond
+	wagerRevealPause = 3 * time.Second
+)
+
+// WagerRound - Manche finale : chaque joueur mise en secret une partie de son score,
+// puis découvre une question difficile. Une bonne réponse rapporte la mise, une
+// mauvaise réponse (ou pas de réponse) la fait perdre. Un joueur ne mise jamais plus
+// que son score : sans points, il joue la question sans rien miser.
+type WagerRound struct {
+	roundBase
+	Question   shared.Question
+	BetTime    time.Duration
+	AnswerTime time.Duration
+
+	phase    string
+	deadline time.Time
+	askedAt  time.Time
+	maxWager map[int]int // Mise maximale de chaque joueur, fixée au début de la manche
+	wagers   map[int]int // Mises placées
+	answers  map[int]int // Choix de chaque joueur (0 à 3)
+	reveal   *shared.WagerRevealPayload
+}
+
+func init() {
+	RegisterRound(shared.RoundWager, func() Round { return NewWagerRound() })
+}
+
+func NewWagerRound() *WagerRound {
+	return &WagerRound{
+		roundBase:  roundBase{kind: shared.RoundWager, name: "Mise finale"},
+		BetTime:    20 * time.Second,
+		AnswerTime: 20 * time.Second,
+	}
+}
+
+func (r *WagerRound) Prepare(game *Game) error {
+	// Question la plus difficile disponible dans les catégories choisies
+	for level := 3; level >= 2; level-- {
+		questions, err := DB.GetQuestions(level, 1, 1, game.Settings.Categories)
+		if err != nil {
+			return err
+		}
+		if len(questions) > 0 {
+			r.Question = questions[0]
+			return nil
+		}
+	}
+	return fmt.Errorf("aucune question disponible pour la mise finale")
+}
+
+func (r *WagerRound) Start(ctx *RoundContext) {
+	r.begin(ctx.Game)
+	r.phase = wagerPhaseBet
+	r.deadline = time.Now().Add(r.BetTime)
+	r.maxWager = make(map[int]int)
+	r.wagers = make(map[int]int)
+	r.answers = make(map[int]int)
+	r.reveal = nil
+	for _, id := range ctx.ActivePlayers() {
+		r.maxWager[id] = max(ctx.Game.Scores[id], 0)
+		r.sendPrompt(ctx, id)
+	}
+	log.Printf("🎲 Mise finale : question %d, %d joueur(s) misent", r.Question.ID, len(r.maxWager))
+}
+
+// sendPrompt invite un joueur à miser
+func (r *WagerRound) sendPrompt(ctx *RoundContext, userID int) {
+	ctx.SendTo(userID, shared.Message{
+		Type: shared.MsgWagerPrompt,
+		Payload: shared.WagerPromptPayload{
+			Category: r.Question.Category,
+			Level:    r.Question.DifficultyLevel,
+			MaxWager: r.maxWager[userID],
+			Seconds:  int(time.Until(r.deadline).Round(time.Second) / time.Second),
+		},
+	})
+}
+
+func (r *WagerRound) HandleMessage(ctx *RoundContext, userID int, msg shared.Message) {
+	switch msg.Type {
+	case shared.MsgPlaceWager:
+		var payload shared.PlaceWagerPayload
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		r.placeWager(ctx, userID, payload.Amount)
+
+	case shared.MsgAnswer:
+		var payload shared.AnswerPayload
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		if r.phase != wagerPhaseQuestion || payload.QuestionID != r.Question.ID || payload.Choice < 0 || payload.Choice > 3 {
+			return
+		}
+		if _, ok := r.maxWager[userID]; !ok {
+			return
+		}
+		if _, done := r.answers[userID]; done {
+			return
+		}
+		// Le verdict reste secret jusqu'à la révélation
+		r.answers[userID] = payload.Choice
+	}
+}
+
+// placeWager enregistre la mise d'un joueur ; elle peut être modifiée tant que la phase de mise dure
+func (r *WagerRound) placeWager(ctx *RoundContext, userID, amount int) {
+	limit, ok := r.maxWager[userID]
+	if !ok || r.phase != wagerPhaseBet {
+		ctx.SendError(userID, "Les mises sont closes")
+		return
+	}
+	if amount < 0 || amount > limit {
+		ctx.SendError(userID, fmt.Sprintf("Mise entre 0 et %d points", limit))
+		return
+	}
+	r.wagers[userID] = amount
+	log.Printf("🎲 Joueur %d a misé", userID)
+	ctx.SendTo(userID, shared.Message{
+		Type:    shared.MsgWagerPlaced,
+		Payload: shared.WagerPlacedPayload{Amount: amount},
+	})
+}
+
+func (r *WagerRound) Tick(ctx *RoundContext, now time.Time) bool {
+	switch r.phase {
+	case wagerPhaseBet:
+		if now.Before(r.deadline) && !r.allDone(ctx, r.wagers) {
+			return false
+		}
+		r.ask(ctx, now)
+
+	case wagerPhaseQuestion:
+		if now.Before(r.deadline) && !r.allDone(ctx, r.answers) {
+			return false
+		}
+		r.resolve(ctx, now)
+
+	case wagerPhaseReveal:
+		return !now.Before(r.deadline)
+	}
+	return false
+}
+
+// allDone indique si tous les joueurs en lice qui misent ont joué l'étape en cours
+// (done contient les mises ou les réponses)
+func (r *WagerRound) allDone(ctx *RoundContext, done map[int]int) bool {
+	for _, id := range ctx.ActivePlayers() {
+		if _, ok := r.maxWager[id]; !ok {
+			continue
+		}
+		if _, ok := done[id]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// ask clôt les mises (sans mise, le joueur mise 0) et pose la question
+func (r *WagerRound) ask(ctx *RoundContext, now time.Time) {
+	r.phase = wagerPhaseQuestion
+	r.askedAt = now
+	r.deadline = now.Add(r.AnswerTime)
+	log.Printf("🎲 Mises closes, question finale %d posée", r.Question.ID)
+	ctx.Broadcast(questionMessage(r.Question, 1))
+	ctx.RecordQCM(r.Question)
+}
+
+// resolve applique les mises et prépare leur révélation, du plus petit au plus grand score final
+func (r *WagerRound) resolve(ctx *RoundContext, now time.Time) {
+	game := ctx.Game
+	q := r.Question
+	reveal := shared.WagerRevealPayload{
+		QuestionID:   q.ID,
+		Answer:       q.CorrectAnswer,
+		AnswerText:   correctOptionText(q),
+		Reveals:      []shared.WagerReveal{},
+		RevealStepMs: int(wagerRevealStep / time.Millisecond),
+	}
+
+	ids := make([]int, 0, len(r.maxWager))
+	for id := range r.maxWager {
+		if _, ok := game.Players[id]; ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		wager := r.wagers[id]
+		choice, answered := r.answers[id]
+		correct := answered && isCorrectChoice(q, choice)
+		points := -wager
+		if correct {
+			points = wager
+			game.Streaks[id]++
+		} else {
+			game.breakStreak(id)
+		}
+		game.Scores[id] += points
+
+		entry := shared.WagerReveal{
+			UserID:   id,
+			Username: game.Players[id].Username,
+			Wager:    wager,
+			Correct:  correct,
+			Points:   points,
+			Score:    game.Scores[id],
+		}
+		if answered {
+			entry.Answer = choiceLetter(choice)
+			ctx.RecordAnswer(shared.TranscriptAnswer{
+				QuestionID: q.ID,
+				UserID:     id,
+				Kind:       shared.ActionAnswer,
+				Answer:     entry.Answer,
+				Correct:    correct,
+				Points:     points,
+			}, r.askedAt)
+		}
+		reveal.Reveals = append(reveal.Reveals, entry)
+		log.Printf("🎲 Joueur %d : mise %d, %+d points", id, wager, points)
+	}
+	sort.SliceStable(reveal.Reveals, func(i, j int) bool {
+		return reveal.Reveals[i].Score < reveal.Reveals[j].Score
+	})
+
+	r.phase = wagerPhaseReveal
+	r.reveal = &reveal
+	r.deadline = now.Add(time.Duration(len(reveal.Reveals))*wagerRevealStep + wagerRevealPause)
+	ctx.Broadcast(shared.Message{Type: shared.MsgWagerReveal, Payload: reveal})
+	ctx.BroadcastScores()
+}
+
+// correctOptionText renvoie le texte de la bonne réponse d'une question QCM
+func correctOptionText(q shared.Question) string {
+	switch q.CorrectAnswer {
+	case "A":
+		return q.ChoiceA
+	case "B":
+		return q.ChoiceB
+	case "C":
+		return q.ChoiceC
+	case "D":
+		return q.ChoiceD
+	}
+	return ""
+}
+
+func (r *WagerRound) Finish(ctx *RoundContext) {
+	r.end(ctx.Game)
+}
+
+// wagerState est l'état sauvegardé d'une manche à mise
+type wagerState struct {
+	baseState
+	Question   shared.Question            `json:"question"`
+	BetTime    time.Duration              `json:"bet_time"`
+	AnswerTime time.Duration              `json:"answer_time"`
+	Phase      string                     `json:"phase"`
+	Deadline   time.Time                  `json:"deadline"`
+	AskedAt    time.Time                  `json:"asked_at"`
+	MaxWager   map[int]int                `json:"max_wager"`
+	Wagers     map[int]int                `json:"wagers"`
+	Answers    map[int]int                `json:"answers"`
+	Reveal     *shared.WagerRevealPayload `json:"reveal"`
+}
+
+func (r *WagerRound) SaveState() (json.RawMessage, error) {
+	return json.Marshal(wagerState{
+		baseState:  r.saveBase(),
+		Question:   r.Question,
+		BetTime:    r.BetTime,
+		AnswerTime: r.AnswerTime,
+		Phase:      r.phase,
+		Deadline:   r.deadline,
+		AskedAt:    r.askedAt,
+		MaxWager:   r.maxWager,
+		Wagers:     r.wagers,
+		Answers:    r.answers,
+		Reveal:     r.reveal,
+	})
+}
+
+func (r *WagerRound) LoadState(data json.RawMessage, offset time.Duration) error {
+	var s wagerState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	r.loadBase(s.baseState)
+	r.Question = s.Question
+	r.BetTime = s.BetTime
+	r.AnswerTime = s.AnswerTime
+	r.phase = s.Phase
+	r.deadline = shiftTime(s.Deadline, offset)
+	r.askedAt = shiftTime(s.AskedAt, offset)
+	r.maxWager = orEmpty(s.MaxWager)
+	r.wagers = orEmpty(s.Wagers)
+	r.answers = orEmpty(s.Answers)
+	r.reveal = s.Reveal
+	return nil
+}
+
+func (r *WagerRound) Resend(ctx *RoundContext, userID int) {
+	_, playing := r.maxWager[userID]
+	switch r.phase {
+	case wagerPhaseBet:
+		if playing {
+			r.sendPrompt(ctx, userID)
+			if amount, ok := r.wagers[userID]; ok {
+				ctx.SendTo(userID, shared.Message{
+					Type:    shared.MsgWagerPlaced,
+					Payload: shared.WagerPlacedPayload{Amount: amount},
+				})
+			}
+		}
+	case wagerPhaseQuestion:
+		if _, answered := r.answers[userID]; !answered {
+			ctx.SendTo(userID, questionMessage(r.Question, 1))
+		}
+	case wagerPhaseReveal:
+		if r.reveal != nil {
+			ctx.SendTo(userID, shared.Message{Type: shared.MsgWagerReveal, Payload: *r.reveal})
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"quiz-app-fyne/shared"
+	"testing"
+)
+
+func TestWagerCaps(t *testing.T) {
+	useTestDB(t)
+	game := newTestGame(t, shared.DefaultGameSettings(), 1, 2, 3)
+	game.Scores = map[int]int{1: 120, 2: 0, 3: -30}
+
+	round := NewWagerRound()
+	round.Question = shared.Question{ID: 9, CorrectAnswer: "A", DifficultyLevel: 3}
+	game.Rounds = []Round{round}
+	game.CurrentRound = 0
+	ctx := &RoundContext{Game: game}
+	round.Start(ctx)
+
+	if round.maxWager[1] != 120 || round.maxWager[2] != 0 || round.maxWager[3] != 0 {
+		t.Fatalf("mises maximales %v, attendu 120, 0 et 0", round.maxWager)
+	}
+
+	steps := []struct {
+		userID, amount int
+		accepted       bool
+	}{
+		{1, 121, false},
+		{1, 120, true},
+		{1, 40, true}, // La mise peut être changée pendant la phase de mise
+		{2, 10, false},
+		{2, 0, true},
+		{3, -5, false},
+		{3, 1, false},
+	}
+	for _, step := range steps {
+		round.placeWager(ctx, step.userID, step.amount)
+		got, has := round.wagers[step.userID]
+		accepted := has && got == step.amount
+		if accepted != step.accepted {
+			t.Errorf("joueur %d mise %d : acceptée %v, attendu %v", step.userID, step.amount, accepted, step.accepted)
+		}
+	}
+	if _, ok := round.wagers[3]; ok {
+		t.Errorf("mise enregistrée pour un joueur au score négatif : %d", round.wagers[3])
+	}
+
+	// Une fois la question posée, les mises sont closes
+	round.phase = wagerPhaseQuestion
+	round.placeWager(ctx, 1, 100)
+	if round.wagers[1] != 40 {
+		t.Errorf("mise modifiée après la clôture : %d, attendu 40", round.wagers[1])
+	}
+}
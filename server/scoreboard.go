@@ -0,0 +1,69 @@
+package server
+
+import (
+	"quiz-app-fyne/shared"
+	"sort"
+)
+
+// buildScoreboard construit le classement courant de la partie.
+// L'appelant doit détenir game.Mutex.
+func (game *Game) buildScoreboard() []shared.ScoreEntry {
+	entries := []shared.ScoreEntry{}
+	for id, player := range game.Players {
+		score := game.Scores[id]
+		entries = append(entries, shared.ScoreEntry{
+			UserID:     id,
+			Username:   player.Username,
+			Score:      score,
+			Delta:      score - game.LastScores[id],
+			Streak:     game.Streaks[id],
+			Multiplier: game.nextMultiplier(id),
+			Team:       game.Teams[id],
+			Eliminated: !game.isActive(id),
+		})
+		game.LastScores[id] = score
+	}
+
+	// Les spectateurs (mode élimination) passent après les joueurs en lice
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Eliminated != entries[j].Eliminated {
+			return !entries[i].Eliminated
+		}
+		if entries[i].Score != entries[j].Score {
+			return entries[i].Score > entries[j].Score
+		}
+		return entries[i].Username < entries[j].Username
+	})
+
+	// Les joueurs à égalité partagent le même rang (1, 2, 2, 4...)
+	for i := range entries {
+		if i > 0 && entries[i].Score == entries[i-1].Score && entries[i].Eliminated == entries[i-1].Eliminated {
+			entries[i].Rank = entries[i-1].Rank
+		} else {
+			entries[i].Rank = i + 1
+		}
+	}
+	return entries
+}
+
+// nextMultiplier renvoie le combo de la prochaine bonne réponse d'un joueur (0 sans combo,
+// pour l'omettre du classement). L'appelant doit détenir game.Mutex.
+func (game *Game) nextMultiplier(userID int) float64 {
+	multiplier := streakMultiplier(game.Settings.StreakMultipliers, game.Streaks[userID])
+	if multiplier <= 1 {
+		return 0
+	}
+	return multiplier
+}
+
+// scoreUpdateMessage construit le message SCORE_UPDATE du classement courant.
+// L'appelant doit détenir game.Mutex.
+func (game *Game) scoreUpdateMessage() shared.Message {
+	return shared.Message{
+		Type: shared.MsgScoreUpdate,
+		Payload: shared.ScoreUpdatePayload{
+			Scores: game.buildScoreboard(),
+			Teams:  game.teamStandings(),
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"crypto/subtle"
+	"sync"
+)
+
+// sessionStore retient le jeton de session remis à chaque joueur à sa connexion.
+// Il prouve l'identité du joueur quand il reprend sa place depuis une nouvelle adresse.
+type sessionStore struct {
+	mutex  sync.Mutex
+	tokens map[int]string
+}
+
+var Sessions = &sessionStore{
+	tokens: make(map[int]string),
+}
+
+// Open ouvre une nouvelle session pour un joueur et renvoie son jeton.
+// La session précédente du joueur n'est plus valable.
+func (s *sessionStore) Open(userID int) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	token := newGameID() // Même tirage aléatoire que les identifiants de partie
+	s.tokens[userID] = token
+	return token
+}
+
+// Check vérifie le jeton présenté par un joueur
+func (s *sessionStore) Check(userID int, token string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	expected, ok := s.tokens[userID]
+	return ok && token != "" && subtle.ConstantTimeCompare([]byte(expected), []byte(token)) == 1
+}
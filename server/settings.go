@@ -0,0 +1,255 @@
+package server
+
+import (
+	"fmt"
+	"quiz-app-fyne/shared"
+	"strings"
+)
+
+// Bornes des paramètres de partie acceptés par le serveur
+const (
+	MaxPlayersPerRoom    = 16
+	MaxRounds            = 5
+	MinQuestionsPerRound = 1
+	MaxQuestionsPerRound = 20
+	MinTimePerQuestion   = 5
+	MaxTimePerQuestion   = 60
+	MaxHintCost          = 100
+	MaxHintBudget        = 500
+	MaxTypoTolerance     = 3
+	MaxRiddleAttempts    = 10
+	MaxPowerUps          = 3
+	MaxStreakTiers       = 5
+	MaxStreakMultiplier  = 3.0
+	MinTeams             = 2
+	MaxTeams             = 4
+)
+
+// ValidateSettings vérifie les paramètres envoyés par l'hôte pour une partie du
+// mode donné ("solo" ou "multi") et les complète
+func ValidateSettings(s shared.GameSettings, mode string) (shared.GameSettings, error) {
+	if s.MaxPlayers < 1 || s.MaxPlayers > MaxPlayersPerRoom {
+		return s, fmt.Errorf("capacité de la salle entre 1 et %d joueurs", MaxPlayersPerRoom)
+	}
+	if mode == "multi" && s.MaxPlayers < MinPlayers {
+		return s, fmt.Errorf("une partie multijoueur accueille au moins %d joueurs", MinPlayers)
+	}
+
+	if len(s.Rounds) == 0 {
+		return s, fmt.Errorf("au moins une manche doit être activée")
+	}
+	if len(s.Rounds) > MaxRounds {
+		return s, fmt.Errorf("%d manches maximum", MaxRounds)
+	}
+	for i, kind := range s.Rounds {
+		if _, ok := roundFactories[kind]; !ok {
+			return s, fmt.Errorf("type de manche inconnu: %s", kind)
+		}
+		if kind == shared.RoundSuddenDeath {
+			return s, fmt.Errorf("la mort subite est réservée au départage des égalités")
+		}
+		if kind == shared.RoundWager && i != len(s.Rounds)-1 {
+			return s, fmt.Errorf("la mise finale se joue en dernière manche")
+		}
+	}
+
+	if s.QuestionsPerRound < MinQuestionsPerRound || s.QuestionsPerRound > MaxQuestionsPerRound {
+		return s, fmt.Errorf("nombre de questions entre %d et %d", MinQuestionsPerRound, MaxQuestionsPerRound)
+	}
+
+	if len(s.DifficultyMix) != 3 {
+		return s, fmt.Errorf("répartition de difficulté invalide (3 niveaux attendus)")
+	}
+	total := 0
+	for _, pct := range s.DifficultyMix {
+		if pct < 0 {
+			return s, fmt.Errorf("répartition de difficulté négative")
+		}
+		total += pct
+	}
+	if total != 100 {
+		return s, fmt.Errorf("la répartition de difficulté doit totaliser 100%% (%d%%)", total)
+	}
+
+	if s.TimePerQuestion < MinTimePerQuestion || s.TimePerQuestion > MaxTimePerQuestion {
+		return s, fmt.Errorf("temps par question entre %d et %d secondes", MinTimePerQuestion, MaxTimePerQuestion)
+	}
+
+	if len(s.HintCosts) != 2 {
+		return s, fmt.Errorf("coûts d'indices invalides (2 attendus)")
+	}
+	for _, cost := range s.HintCosts {
+		if cost < 0 || cost > MaxHintCost {
+			return s, fmt.Errorf("coût d'indice entre 0 et %d", MaxHintCost)
+		}
+	}
+
+	if s.HintBudget < 0 || s.HintBudget > MaxHintBudget {
+		return s, fmt.Errorf("budget d'indices entre 0 et %d", MaxHintBudget)
+	}
+
+	if s.TypoTolerance < 0 || s.TypoTolerance > MaxTypoTolerance {
+		return s, fmt.Errorf("tolérance aux fautes entre 0 et %d", MaxTypoTolerance)
+	}
+
+	if s.RiddleAttempts < 1 || s.RiddleAttempts > MaxRiddleAttempts {
+		return s, fmt.Errorf("essais pour la devinette entre 1 et %d", MaxRiddleAttempts)
+	}
+
+	if s.PowerUps < 0 || s.PowerUps > MaxPowerUps {
+		return s, fmt.Errorf("utilisations de chaque joker entre 0 et %d", MaxPowerUps)
+	}
+
+	if len(s.StreakMultipliers) > MaxStreakTiers {
+		return s, fmt.Errorf("%d paliers de combo maximum", MaxStreakTiers)
+	}
+	for i, tier := range s.StreakMultipliers {
+		if tier.Streak < 1 || tier.Multiplier < 1 || tier.Multiplier > MaxStreakMultiplier {
+			return s, fmt.Errorf("palier de combo invalide (série d'au moins 1, multiplicateur entre 1 et %g)", MaxStreakMultiplier)
+		}
+		if i > 0 && (tier.Streak <= s.StreakMultipliers[i-1].Streak || tier.Multiplier <= s.StreakMultipliers[i-1].Multiplier) {
+			return s, fmt.Errorf("les paliers de combo doivent être croissants")
+		}
+	}
+	if s.StreakMultipliers == nil {
+		s.StreakMultipliers = []shared.StreakMultiplier{}
+	}
+
+	switch {
+	case s.Teams == 0:
+		s.TeamScoring = ""
+	case s.Teams < MinTeams || s.Teams > MaxTeams:
+		return s, fmt.Errorf("nombre d'équipes entre %d et %d", MinTeams, MaxTeams)
+	case s.Teams > s.MaxPlayers:
+		return s, fmt.Errorf("plus d'équipes que de places dans la salle")
+	default:
+		switch s.TeamScoring {
+		case "":
+			s.TeamScoring = shared.TeamScoreSum
+		case shared.TeamScoreSum, shared.TeamScoreAverage, shared.TeamScoreBest:
+		default:
+			return s, fmt.Errorf("calcul du score d'équipe inconnu: %s", s.TeamScoring)
+		}
+	}
+
+	// Seul, un joueur n'a ni équipe adverse ni adversaire à éliminer
+	if mode == "solo" && s.Teams > 0 {
+		return s, fmt.Errorf("les équipes se jouent en multijoueur")
+	}
+	if mode == "solo" && s.Elimination {
+		return s, fmt.Errorf("le mode élimination se joue en multijoueur")
+	}
+
+	if s.Elimination {
+		if s.Teams > 0 {
+			return s, fmt.Errorf("le mode élimination se joue sans équipes")
+		}
+		if s.EliminatePerBlock == 0 {
+			s.EliminatePerBlock = 1
+		}
+		if s.EliminatePerBlock < 1 || s.EliminatePerBlock >= s.MaxPlayers {
+			return s, fmt.Errorf("joueurs éliminés par manche entre 1 et %d", s.MaxPlayers-1)
+		}
+	} else {
+		s.EliminatePerBlock = 0
+	}
+
+	// Départage des égalités, sans objet en équipes ou à élimination
+	switch s.TieBreak {
+	case "":
+		s.TieBreak = shared.TieBreakFirst
+	case shared.TieBreakNone, shared.TieBreakFirst, shared.TieBreakPodium:
+	default:
+		return s, fmt.Errorf("départage inconnu: %s", s.TieBreak)
+	}
+	if s.Teams > 0 || s.Elimination {
+		s.TieBreak = shared.TieBreakNone
+	}
+
+	categories := []string{}
+	if len(s.Categories) > 0 {
+		known, err := DB.GetCategories()
+		if err != nil {
+			return s, fmt.Errorf("catégories indisponibles: %v", err)
+		}
+		for _, c := range s.Categories {
+			c = strings.TrimSpace(c)
+			if c == "" {
+				continue
+			}
+			found := false
+			for _, k := range known {
+				if strings.EqualFold(c, k) {
+					categories = append(categories, k)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return s, fmt.Errorf("catégorie inconnue: %s", c)
+			}
+		}
+	}
+	s.Categories = categories
+
+	if err := checkRoundContent(s); err != nil {
+		return s, err
+	}
+	return s, nil
+}
+
+// checkRoundContent prépare chaque manche à blanc pour refuser dès la création une
+// partie qui ne pourrait pas démarrer (aucune question pour ces catégories...)
+func checkRoundContent(s shared.GameSettings) error {
+	scratch := &Game{Settings: s}
+	for _, kind := range s.Rounds {
+		round, err := NewRound(kind)
+		if err != nil {
+			return err
+		}
+		if err := round.Prepare(scratch); err != nil {
+			return fmt.Errorf("manche %s impossible avec ces paramètres: %v", round.Name(), err)
+		}
+	}
+	return nil
+}
+
+// questionCounts répartit le nombre de questions entre les niveaux 1 à 3
+// selon les pourcentages de DifficultyMix
+func questionCounts(s shared.GameSettings) []int {
+	counts := make([]int, len(s.DifficultyMix))
+	assigned := 0
+	for i, pct := range s.DifficultyMix {
+		counts[i] = s.QuestionsPerRound * pct / 100
+		assigned += counts[i]
+	}
+	// Le reste va aux niveaux les plus représentés
+	for assigned < s.QuestionsPerRound {
+		best := 0
+		for i, pct := range s.DifficultyMix {
+			if pct > s.DifficultyMix[best] || (pct == s.DifficultyMix[best] && counts[i] < counts[best]) {
+				best = i
+			}
+		}
+		counts[best]++
+		assigned++
+	}
+	return counts
+}
+
+// loadQuestions tire les questions de la manche de la base (1 ou 2) réparties
+// entre les niveaux selon questionCounts, dans les catégories choisies par l'hôte
+func loadQuestions(settings shared.GameSettings, manche int) ([]shared.Question, error) {
+	var questions []shared.Question
+	for i, count := range questionCounts(settings) {
+		if count == 0 {
+			continue
+		}
+		q, err := DB.GetQuestions(i+1, manche, count, settings.Categories)
+		if err != nil {
+			return nil, err
+		}
+		questions = append(questions, q...)
+	}
+	return questions, nil
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"quiz-app-fyne/shared"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestQuestionCounts(t *testing.T) {
+	tests := []struct {
+		questions int
+		mix       []int
+		want      []int
+	}{
+		{8, []int{50, 50, 0}, []int{4, 4, 0}},
+		{7, []int{50, 50, 0}, []int{4, 3, 0}}, // À égalité, le reste va au premier niveau
+		{10, []int{34, 33, 33}, []int{4, 3, 3}},
+		{1, []int{33, 33, 34}, []int{0, 0, 1}},
+		{5, []int{0, 100, 0}, []int{0, 5, 0}},
+		{20, []int{10, 20, 70}, []int{2, 4, 14}},
+	}
+	for _, tt := range tests {
+		s := shared.GameSettings{QuestionsPerRound: tt.questions, DifficultyMix: tt.mix}
+		if got := questionCounts(s); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("questionCounts(%d, %v) = %v, attendu %v", tt.questions, tt.mix, got, tt.want)
+		}
+	}
+}
+
+// Les refus testés ici tombent tous avant la lecture des catégories et la
+// préparation des manches, qui demandent la base de données
+func TestValidateSettingsRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   string
+		change func(s *shared.GameSettings)
+		want   string
+	}{
+		{"salle vide", "multi", func(s *shared.GameSettings) { s.MaxPlayers = 0 }, "capacité de la salle"},
+		{"salle trop grande", "multi", func(s *shared.GameSettings) { s.MaxPlayers = MaxPlayersPerRoom + 1 }, "capacité de la salle"},
+		{"multijoueur à un joueur", "multi", func(s *shared.GameSettings) { s.MaxPlayers = 1 }, "au moins"},
+		{"aucune manche", "multi", func(s *shared.GameSettings) { s.Rounds = nil }, "au moins une manche"},
+		{"manche inconnue", "multi", func(s *shared.GameSettings) { s.Rounds = []string{"karaoke"} }, "type de manche inconnu"},
+		{"mort subite choisie", "multi", func(s *shared.GameSettings) { s.Rounds = []string{shared.RoundSuddenDeath} }, "départage"},
+		{"mise avant la fin", "multi", func(s *shared.GameSettings) { s.Rounds = []string{shared.RoundWager, shared.RoundQCM} }, "dernière manche"},
+		{"aucune question", "multi", func(s *shared.GameSettings) { s.QuestionsPerRound = 0 }, "nombre de questions"},
+		{"répartition incomplète", "multi", func(s *shared.GameSettings) { s.DifficultyMix = []int{50, 40, 0} }, "totaliser 100%"},
+		{"répartition négative", "multi", func(s *shared.GameSettings) { s.DifficultyMix = []int{120, -20, 0} }, "négative"},
+		{"temps trop court", "multi", func(s *shared.GameSettings) { s.TimePerQuestion = MinTimePerQuestion - 1 }, "temps par question"},
+		{"paliers décroissants", "multi", func(s *shared.GameSettings) {
+			s.StreakMultipliers = []shared.StreakMultiplier{{Streak: 5, Multiplier: 2}, {Streak: 3, Multiplier: 1.5}}
+		}, "croissants"},
+		{"une seule équipe", "multi", func(s *shared.GameSettings) { s.Teams = 1 }, "nombre d'équipes"},
+		{"plus d'équipes que de places", "multi", func(s *shared.GameSettings) { s.MaxPlayers = 2; s.Teams = 3 }, "plus d'équipes"},
+		{"équipes en solo", "solo", func(s *shared.GameSettings) { s.Teams = 2 }, "multijoueur"},
+		{"élimination en solo", "solo", func(s *shared.GameSettings) { s.Elimination = true }, "multijoueur"},
+		{"élimination en équipes", "multi", func(s *shared.GameSettings) { s.Elimination = true; s.Teams = 2 }, "sans équipes"},
+		{"élimination de toute la salle", "multi", func(s *shared.GameSettings) { s.Elimination = true; s.EliminatePerBlock = s.MaxPlayers }, "joueurs éliminés"},
+		{"départage inconnu", "multi", func(s *shared.GameSettings) { s.TieBreak = "pile ou face" }, "départage inconnu"},
+	}
+	for _, tt := range tests {
+		s := shared.DefaultGameSettings()
+		tt.change(&s)
+		_, err := ValidateSettings(s, tt.mode)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s : erreur %v, attendu une erreur contenant %q", tt.name, err, tt.want)
+		}
+	}
+}
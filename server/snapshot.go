@@ -0,0 +1,369 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"quiz-app-fyne/shared"
+	"time"
+)
+
+// Fréquence des sauvegardes périodiques des parties en cours
+const SnapshotInterval = 5 * time.Second
+
+// Les manches livrées avec le serveur reprennent après un redémarrage
+var (
+	_ ResumableRound = (*QCMRound)(nil)
+	_ ResumableRound = (*TimeAttackRound)(nil)
+	_ ResumableRound = (*RiddleRound)(nil)
+	_ ResumableRound = (*BuzzerRound)(nil)
+	_ ResumableRound = (*WagerRound)(nil)
+	_ ResumableRound = (*SuddenDeathRound)(nil)
+)
+
+// gameSnapshot est l'image d'une partie sauvegardée dans SQLite
+type gameSnapshot struct {
+	ID           string                 `json:"id"`
+	Code         string                 `json:"code"`
+	Mode         string                 `json:"mode"`
+	Settings     shared.GameSettings    `json:"settings"`
+	State        string                 `json:"state"`
+	StopReason   string                 `json:"stop_reason"`
+	Players      []snapshotPlayer       `json:"players"`
+	Scores       map[int]int            `json:"scores"`
+	HostID       int                    `json:"host_id"`
+	Ready        map[int]bool           `json:"ready"`
+	Locked       bool                   `json:"locked"`
+	PasswordHash string                 `json:"password_hash"`
+	Public       bool                   `json:"public"`
+	Kicked       map[int]bool           `json:"kicked"`
+	Teams        map[int]int            `json:"teams"`
+	Eliminated   map[int]int            `json:"eliminated"`
+	AnswerMs     map[int]int            `json:"answer_ms"`
+	TieBreaks    map[int]int            `json:"tie_breaks"`
+	CountdownEnd time.Time              `json:"countdown_end"`
+	Rounds       []snapshotRound        `json:"rounds"`
+	CurrentRound int                    `json:"current_round"`
+	RoundResults []RoundResult          `json:"round_results"`
+	HintSpent    map[int]int            `json:"hint_spent"`
+	PowerUpsUsed map[int]map[string]int `json:"power_ups_used"`
+	Streaks      map[int]int            `json:"streaks"`
+	LastScores   map[int]int            `json:"last_scores"`
+	SavedAt      time.Time              `json:"saved_at"`
+}
+
+type snapshotPlayer struct {
+	ID       int    `json:"id"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+	Rating   int    `json:"rating"`
+	Addr     string `json:"addr"` // Dernière adresse UDP connue
+}
+
+type snapshotRound struct {
+	Kind  string          `json:"kind"`
+	State json.RawMessage `json:"state,omitempty"` // Vide : la manche sera préparée à nouveau
+}
+
+// snapshot sauvegarde la partie en arrière-plan.
+// L'appelant doit détenir game.Mutex.
+func (game *Game) snapshot() {
+	s := gameSnapshot{
+		ID:           game.ID,
+		Code:         game.Code,
+		Mode:         game.Mode,
+		Settings:     game.Settings,
+		State:        game.State,
+		StopReason:   game.StopReason,
+		Scores:       game.Scores,
+		HostID:       game.HostID,
+		Ready:        game.Ready,
+		Locked:       game.Locked,
+		PasswordHash: game.PasswordHash,
+		Public:       game.Public,
+		Kicked:       game.Kicked,
+		Teams:        game.Teams,
+		Eliminated:   game.Eliminated,
+		AnswerMs:     game.AnswerMs,
+		TieBreaks:    game.TieBreaks,
+		CountdownEnd: game.CountdownEnd,
+		CurrentRound: game.CurrentRound,
+		RoundResults: game.RoundResults,
+		HintSpent:    game.HintSpent,
+		PowerUpsUsed: game.PowerUpsUsed,
+		Streaks:      game.Streaks,
+		LastScores:   game.LastScores,
+		SavedAt:      time.Now(),
+	}
+	for _, p := range game.Players {
+		player := snapshotPlayer{ID: p.ID, Email: p.Email, Username: p.Username, Rating: p.Rating}
+		if p.Addr != nil {
+			player.Addr = p.Addr.String()
+		}
+		s.Players = append(s.Players, player)
+	}
+	for _, round := range game.Rounds {
+		sr := snapshotRound{Kind: round.Kind()}
+		if resumable, ok := round.(ResumableRound); ok {
+			state, err := resumable.SaveState()
+			if err != nil {
+				log.Printf("⚠️ Partie %s : manche %s non sauvegardée: %v", game.Code, round.Name(), err)
+			} else {
+				sr.State = state
+			}
+		}
+		s.Rounds = append(s.Rounds, sr)
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		log.Printf("❌ Sauvegarde partie %s impossible: %v", game.Code, err)
+		return
+	}
+	id := game.ID
+	History.enqueue(func() error {
+		return DB.SaveSnapshot(id, data)
+	})
+}
+
+// dropSnapshot supprime la sauvegarde d'une partie terminée
+func (game *Game) dropSnapshot() {
+	id := game.ID
+	History.enqueue(func() error {
+		return DB.DeleteSnapshot(id)
+	})
+}
+
+// restore reconstruit une partie à partir de sa sauvegarde. Les échéances sont
+// décalées de la durée d'arrêt du serveur.
+func (s *gameSnapshot) restore() (*Game, error) {
+	offset := time.Since(s.SavedAt)
+	game := &Game{
+		ID:           s.ID,
+		Code:         s.Code,
+		Mode:         s.Mode,
+		Settings:     s.Settings,
+		State:        s.State,
+		StopReason:   s.StopReason,
+		Players:      make(map[int]*shared.User, len(s.Players)),
+		Scores:       orEmpty(s.Scores),
+		HostID:       s.HostID,
+		Ready:        s.Ready,
+		Locked:       s.Locked,
+		PasswordHash: s.PasswordHash,
+		Public:       s.Public,
+		Kicked:       s.Kicked,
+		Teams:        orEmpty(s.Teams),
+		Eliminated:   orEmpty(s.Eliminated),
+		AnswerMs:     orEmpty(s.AnswerMs),
+		TieBreaks:    orEmpty(s.TieBreaks),
+		CountdownEnd: shiftTime(s.CountdownEnd, offset),
+		CurrentRound: s.CurrentRound,
+		RoundResults: s.RoundResults,
+		HintSpent:    orEmpty(s.HintSpent),
+		PowerUpsUsed: s.PowerUpsUsed,
+		Streaks:      orEmpty(s.Streaks),
+		LastScores:   orEmpty(s.LastScores),
+	}
+	if game.Ready == nil {
+		game.Ready = make(map[int]bool)
+	}
+	if game.Kicked == nil {
+		game.Kicked = make(map[int]bool)
+	}
+	if game.PowerUpsUsed == nil {
+		game.PowerUpsUsed = make(map[int]map[string]int)
+	}
+
+	for _, p := range s.Players {
+		user := &shared.User{ID: p.ID, Email: p.Email, Username: p.Username, Rating: p.Rating}
+		if p.Addr != "" {
+			if addr, err := net.ResolveUDPAddr("udp", p.Addr); err == nil {
+				user.Addr = addr
+			}
+		}
+		game.Players[p.ID] = user
+	}
+
+	for _, sr := range s.Rounds {
+		round, err := NewRound(sr.Kind)
+		if err != nil {
+			return nil, err
+		}
+		resumable, ok := round.(ResumableRound)
+		if len(sr.State) > 0 && ok {
+			if err := resumable.LoadState(sr.State, offset); err != nil {
+				return nil, fmt.Errorf("manche %s: %w", sr.Kind, err)
+			}
+		} else if game.State != shared.GameStateLobby && game.State != shared.GameStateCountdown {
+			if err := round.Prepare(game); err != nil {
+				return nil, fmt.Errorf("manche %s: %w", sr.Kind, err)
+			}
+		}
+		game.Rounds = append(game.Rounds, round)
+	}
+	return game, nil
+}
+
+func orEmpty(m map[int]int) map[int]int {
+	if m == nil {
+		return make(map[int]int)
+	}
+	return m
+}
+
+// RestoreGames recharge au démarrage les parties sauvegardées puis lance les
+// sauvegardes périodiques. Les parties en cours reprennent là où elles en étaient.
+func (gm *GameManager) RestoreGames(conn *net.UDPConn) {
+	gm.Conn = conn
+	snapshots, err := DB.LoadSnapshots()
+	if err != nil {
+		log.Printf("❌ Lecture des sauvegardes impossible: %v", err)
+	}
+
+	for _, data := range snapshots {
+		var s gameSnapshot
+		if err := json.Unmarshal(data, &s); err != nil {
+			log.Printf("⚠️ Sauvegarde illisible ignorée: %v", err)
+			continue
+		}
+		game, err := s.restore()
+		if err != nil {
+			log.Printf("⚠️ Partie %s non restaurée: %v", s.Code, err)
+			DB.DeleteSnapshot(s.ID)
+			continue
+		}
+
+		gm.Mutex.Lock()
+		gm.Games[game.Code] = game
+		gm.Mutex.Unlock()
+		log.Printf("♻️ Partie %s restaurée (%s, %d joueurs)", game.Code, game.State, len(game.Players))
+		gm.resume(conn, game)
+	}
+
+	go gm.snapshotLoop()
+}
+
+// resume relance ce qui tournait pour la partie au moment de l'arrêt
+func (gm *GameManager) resume(conn *net.UDPConn, game *Game) {
+	game.Mutex.Lock()
+	defer game.Mutex.Unlock()
+
+	switch game.State {
+	case shared.GameStateLobby:
+		game.broadcastLobby(conn)
+
+	case shared.GameStateCountdown:
+		game.countdownID++
+		game.broadcastLobby(conn)
+		go gm.runCountdown(conn, game, game.countdownID)
+
+	case shared.GameStateInRound:
+		// La manche en cours reprend sans être relancée
+		first := game.CurrentRound
+		if first < 0 {
+			first = 0
+		} else {
+			game.resumed = true
+		}
+		go gm.playRounds(conn, game, first)
+
+	case shared.GameStateReveal:
+		go gm.playRounds(conn, game, game.CurrentRound+1)
+
+	case shared.GameStateFinished:
+		// Fin de partie interrompue : l'enregistrement est idempotent
+		go func() {
+			gm.recordGameEnd(game)
+			game.dropSnapshot()
+			go gm.cleanupGame(game.Code)
+		}()
+
+	default:
+		game.dropSnapshot()
+	}
+}
+
+// resumeRound reprend la manche en cours après une restauration : chaque joueur
+// reçoit de nouveau l'écran qu'il avait. L'appelant doit détenir game.Mutex.
+func (game *Game) resumeRound(ctx *RoundContext, round Round) {
+	resumable, ok := round.(ResumableRound)
+	if !ok {
+		round.Start(ctx)
+		return
+	}
+	for id := range game.Players {
+		resumable.Resend(ctx, id)
+	}
+	ctx.BroadcastScores()
+}
+
+// snapshotLoop sauvegarde régulièrement les parties qui ne sont pas terminées
+func (gm *GameManager) snapshotLoop() {
+	ticker := time.NewTicker(SnapshotInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		gm.Mutex.RLock()
+		games := make([]*Game, 0, len(gm.Games))
+		for _, game := range gm.Games {
+			games = append(games, game)
+		}
+		gm.Mutex.RUnlock()
+
+		for _, game := range games {
+			game.Mutex.Lock()
+			if !game.isOver() {
+				game.snapshot()
+			}
+			game.Mutex.Unlock()
+		}
+	}
+}
+
+// ResumePlayer renvoie à un joueur qui se reconnecte l'état de sa partie. Une nouvelle
+// adresse n'est acceptée qu'avec le jeton de session du joueur.
+func (gm *GameManager) ResumePlayer(conn *net.UDPConn, userID int, token string, addr *net.UDPAddr) bool {
+	game := gm.findPlayerGame(userID)
+	if game == nil {
+		return false
+	}
+
+	game.Mutex.Lock()
+	defer game.Mutex.Unlock()
+
+	player, ok := game.Players[userID]
+	if !ok {
+		return false
+	}
+	sameAddr := player.Addr != nil && player.Addr.String() == addr.String()
+	if !sameAddr && !Sessions.Check(userID, token) {
+		log.Printf("⛔ Reprise refusée pour le joueur %d depuis %s : session invalide", userID, addr)
+		return false
+	}
+	player.Addr = addr
+	log.Printf("🔌 Joueur %d reconnecté à la partie %s", userID, game.Code)
+
+	SendResponse(conn, addr, shared.Message{
+		Type: shared.MsgJoinGame,
+		Payload: shared.GameJoinedPayload{
+			GameCode: game.Code,
+			Mode:     game.Mode,
+			HostID:   game.HostID,
+			Private:  game.PasswordHash != "",
+			Settings: game.Settings,
+		},
+	})
+	if game.isJoinable() {
+		SendResponse(conn, addr, game.lobbyMessage())
+		return true
+	}
+	SendResponse(conn, addr, game.stateMessage(""))
+	if game.State == shared.GameStateInRound && game.CurrentRound >= 0 && game.CurrentRound < len(game.Rounds) {
+		if resumable, ok := game.Rounds[game.CurrentRound].(ResumableRound); ok {
+			resumable.Resend(&RoundContext{Conn: conn, Game: game}, userID)
+		}
+	}
+	return true
+}
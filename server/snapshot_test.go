@@ -0,0 +1,117 @@
+package server
+
+import (
+	"encoding/json"
+	"net"
+	"quiz-app-fyne/shared"
+	"reflect"
+	"testing"
+)
+
+// reloadSnapshot relit dans la base la sauvegarde d'une partie et la reconstruit
+func reloadSnapshot(t *testing.T, game *Game) *Game {
+	t.Helper()
+	game.Mutex.Lock()
+	game.snapshot()
+	game.Mutex.Unlock()
+	History.Flush()
+
+	snapshots, err := DB.LoadSnapshots()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, data := range snapshots {
+		var s gameSnapshot
+		if err := json.Unmarshal(data, &s); err != nil {
+			t.Fatal(err)
+		}
+		if s.ID != game.ID {
+			continue
+		}
+		restored, err := s.restore()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return restored
+	}
+	t.Fatalf("aucune sauvegarde pour la partie %s", game.Code)
+	return nil
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	round, ctx := startRiddle(t, 1, 2)
+	game := ctx.Game
+	game.State = shared.GameStateInRound
+	game.PasswordHash = hashRoomPassword(game.ID, "sésame")
+	round.HandleMessage(ctx, 1, riddleAnswer(1, "girafe"))
+	round.HandleMessage(ctx, 2, riddleAnswer(2, "éléphant"))
+	game.Players[1].Addr = &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 4000}
+
+	restored := reloadSnapshot(t, game)
+
+	if restored.Code != game.Code || restored.State != game.State || restored.HostID != game.HostID {
+		t.Errorf("partie restaurée %s (%s, hôte %d), attendu %s (%s, hôte %d)",
+			restored.Code, restored.State, restored.HostID, game.Code, game.State, game.HostID)
+	}
+	if restored.PasswordHash != game.PasswordHash {
+		t.Error("mot de passe de la salle perdu")
+	}
+	if !reflect.DeepEqual(restored.Scores, game.Scores) {
+		t.Errorf("scores %v, attendu %v", restored.Scores, game.Scores)
+	}
+	if addr := restored.Players[1].Addr; addr == nil || addr.String() != "127.0.0.1:4000" {
+		t.Errorf("adresse du joueur 1 : %v, attendu 127.0.0.1:4000", addr)
+	}
+
+	riddle, ok := restored.Rounds[0].(*RiddleRound)
+	if !ok {
+		t.Fatalf("manche restaurée de type %T, attendu *RiddleRound", restored.Rounds[0])
+	}
+	if riddle.Riddle.CorrectWord != "éléphant" || riddle.attempts[1] != 1 || !riddle.solved[2] {
+		t.Errorf("devinette restaurée : réponse %q, essais %v, trouvé %v",
+			riddle.Riddle.CorrectWord, riddle.attempts, riddle.solved)
+	}
+	if riddle.deadline.Before(round.deadline) {
+		t.Errorf("échéance avancée par la restauration : %s, avant %s", riddle.deadline, round.deadline)
+	}
+}
+
+// listenTestConn ouvre une socket locale pour les messages envoyés aux joueurs
+func listenTestConn(t *testing.T) *net.UDPConn {
+	t.Helper()
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestResumePlayerNeedsSession(t *testing.T) {
+	useTestDB(t)
+	conn := listenTestConn(t)
+	game := newTestGame(t, shared.DefaultGameSettings(), 1, 2)
+	known := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 4001}
+	other := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 4002}
+	game.Players[1].Addr = known
+	token := Sessions.Open(1)
+
+	if Manager.ResumePlayer(conn, 1, "", other) {
+		t.Error("reprise acceptée depuis une nouvelle adresse sans jeton")
+	}
+	if Manager.ResumePlayer(conn, 1, "mauvais-jeton", other) {
+		t.Error("reprise acceptée avec un jeton invalide")
+	}
+	if game.Players[1].Addr != known {
+		t.Fatal("adresse changée par une reprise refusée")
+	}
+	if !Manager.ResumePlayer(conn, 1, "", known) {
+		t.Error("reprise refusée depuis l'adresse connue")
+	}
+	if !Manager.ResumePlayer(conn, 1, token, other) || game.Players[1].Addr != other {
+		t.Error("reprise refusée avec le jeton de session")
+	}
+	if Manager.ResumePlayer(conn, 3, token, other) {
+		t.Error("reprise acceptée pour un joueur absent de la partie")
+	}
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"math"
+	"quiz-app-fyne/shared"
+)
+
+// streakMultiplier renvoie le multiplicateur de combo d'une série de bonnes réponses :
+// celui du plus haut palier atteint (1 sans palier)
+func streakMultiplier(tiers []shared.StreakMultiplier, streak int) float64 {
+	multiplier := 1.0
+	for _, tier := range tiers {
+		if streak >= tier.Streak {
+			multiplier = tier.Multiplier
+		}
+	}
+	return multiplier
+}
+
+// scoreCorrect prolonge la série d'un joueur et renvoie les points d'une bonne réponse,
+// multipliés selon la série atteinte avant cette réponse, avec le multiplicateur appliqué.
+// L'appelant doit détenir game.Mutex.
+func (game *Game) scoreCorrect(userID, base int) (int, float64) {
+	multiplier := streakMultiplier(game.Settings.StreakMultipliers, game.Streaks[userID])
+	game.Streaks[userID]++
+	return int(math.Round(float64(base) * multiplier)), multiplier
+}
+
+// breakStreak remet à zéro la série d'un joueur (mauvaise réponse ou question sans réponse).
+// L'appelant doit détenir game.Mutex.
+func (game *Game) breakStreak(userID int) {
+	game.Streaks[userID] = 0
+}
+
+// answerResult construit le verdict d'une réponse envoyé au joueur.
+// L'appelant doit détenir game.Mutex.
+func (game *Game) answerResult(userID, questionID int, correct bool, points int, multiplier float64) shared.Message {
+	return shared.Message{
+		Type: shared.MsgAnswerResult,
+		Payload: shared.AnswerResultPayload{
+			QuestionID: questionID,
+			Correct:    correct,
+			Points:     points,
+			Streak:     game.Streaks[userID],
+			Multiplier: multiplier,
+		},
+	}
+}
@@ -0,0 +1,29 @@
+package server
+
+import (
+	"quiz-app-fyne/shared"
+	"testing"
+)
+
+func TestStreakMultiplier(t *testing.T) {
+	tiers := []shared.StreakMultiplier{{Streak: 3, Multiplier: 1.5}, {Streak: 5, Multiplier: 2}}
+	tests := []struct {
+		tiers  []shared.StreakMultiplier
+		streak int
+		want   float64
+	}{
+		{nil, 10, 1},
+		{tiers, 0, 1},
+		{tiers, 2, 1},
+		{tiers, 3, 1.5},
+		{tiers, 4, 1.5},
+		{tiers, 5, 2},
+		{tiers, 12, 2},
+		{[]shared.StreakMultiplier{{Streak: 1, Multiplier: 1.2}}, 1, 1.2},
+	}
+	for _, tt := range tests {
+		if got := streakMultiplier(tt.tiers, tt.streak); got != tt.want {
+			t.Errorf("streakMultiplier(%v, %d) = %g, attendu %g", tt.tiers, tt.streak, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,374 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"quiz-app-fyne/shared"
+	"sort"
+	"time"
+)
+
+// Nombre de questions préparées pour une mort subite
+const suddenDeathQuestions = 10
+
+// Pauses de la mort subite : annonce avant la première question, puis résultat de chaque question
+const (
+	suddenDeathIntro       = 3 * time.Second
+	suddenDeathRevealDelay = 2 * time.Second
+)
+
+// SuddenDeathRound - Départage des joueurs à égalité pour une place du podium, lancé
+// par le serveur en fin de partie. Seuls les joueurs à départager répondent, les
+// autres regardent. Après chaque question, ceux qui se sont trompés (ou n'ont pas
+// répondu) sont écartés si un adversaire a trouvé ; si personne ne trouve, tous restent
+// en course. La mort subite ne rapporte aucun point : elle ne fait qu'ordonner les
+// joueurs à égalité (voir Game.TieBreaks).
+type SuddenDeathRound struct {
+	roundBase
+	Questions       []shared.Question
+	TimePerQuestion time.Duration
+	Place           int   // Place disputée
+	Players         []int // Joueurs à départager
+
+	current   int // -1 pendant l'annonce
+	askedAt   time.Time
+	deadline  time.Time
+	closedAt  time.Time // Fin de la question courante ou de l'annonce (zéro tant que la question est ouverte)
+	remaining []int     // Joueurs encore en course
+	out       [][]int   // Joueurs écartés, question par question
+	answers   map[int]int
+}
+
+func init() {
+	RegisterRound(shared.RoundSuddenDeath, func() Round { return NewSuddenDeathRound() })
+}
+
+func NewSuddenDeathRound() *SuddenDeathRound {
+	return &SuddenDeathRound{
+		roundBase:       roundBase{kind: shared.RoundSuddenDeath, name: "Mort subite"},
+		TimePerQuestion: 10 * time.Second,
+	}
+}
+
+func (r *SuddenDeathRound) Prepare(game *Game) error {
+	// Questions difficiles d'abord, complétées par des questions plus faciles
+	r.Questions = nil
+	for level := 3; level >= 1 && len(r.Questions) < suddenDeathQuestions; level-- {
+		questions, err := DB.GetQuestions(level, 1, suddenDeathQuestions-len(r.Questions), game.Settings.Categories)
+		if err != nil {
+			return err
+		}
+		r.Questions = append(r.Questions, questions...)
+	}
+	if len(r.Questions) == 0 {
+		return fmt.Errorf("aucune question disponible pour la mort subite")
+	}
+	r.TimePerQuestion = time.Duration(game.Settings.TimePerQuestion) * time.Second
+	return nil
+}
+
+func (r *SuddenDeathRound) Start(ctx *RoundContext) {
+	r.begin(ctx.Game)
+	r.current = -1
+	r.closedAt = time.Now()
+	r.remaining = append([]int(nil), r.Players...)
+	r.out = nil
+	r.answers = make(map[int]int)
+	log.Printf("⚡ Partie %s - mort subite pour la place %d entre %v", ctx.Game.Code, r.Place, r.Players)
+	ctx.Broadcast(r.statusMessage(ctx, ""))
+}
+
+// statusMessage construit l'annonce ou l'avancement de la mort subite
+// (answer est la bonne réponse de la question qui vient de se terminer)
+func (r *SuddenDeathRound) statusMessage(ctx *RoundContext, answer string) shared.Message {
+	payload := shared.SuddenDeathPayload{
+		Place:     r.Place,
+		Players:   r.Players,
+		Names:     []string{},
+		Remaining: r.remaining,
+		Answer:    answer,
+	}
+	for _, id := range r.Players {
+		name := ""
+		if player, ok := ctx.Game.Players[id]; ok {
+			name = player.Username
+		}
+		payload.Names = append(payload.Names, name)
+	}
+	if len(r.remaining) == 1 {
+		payload.WinnerID = r.remaining[0]
+	}
+	return shared.Message{Type: shared.MsgSuddenDeath, Payload: payload}
+}
+
+// contender indique si un joueur est encore en course
+func (r *SuddenDeathRound) contender(userID int) bool {
+	for _, id := range r.remaining {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *SuddenDeathRound) sendCurrent(ctx *RoundContext, now time.Time) {
+	q := r.Questions[r.current]
+	log.Printf("⚡ Question de mort subite %d/%d envoyée", r.current+1, len(r.Questions))
+	r.askedAt = now
+	r.deadline = now.Add(r.TimePerQuestion)
+	r.closedAt = time.Time{}
+	r.answers = make(map[int]int)
+	// Tout le monde voit la question, seuls les joueurs en course peuvent répondre
+	ctx.Broadcast(questionMessage(q, 1))
+	ctx.RecordQCM(q)
+}
+
+func (r *SuddenDeathRound) HandleMessage(ctx *RoundContext, userID int, msg shared.Message) {
+	if msg.Type != shared.MsgAnswer || r.current < 0 || !r.closedAt.IsZero() {
+		return
+	}
+	var payload shared.AnswerPayload
+	if err := decodePayload(msg, &payload); err != nil {
+		return
+	}
+	if payload.QuestionID != r.Questions[r.current].ID || payload.Choice < 0 || payload.Choice > 3 {
+		return
+	}
+	if !r.contender(userID) {
+		ctx.SendError(userID, "Seuls les joueurs à égalité répondent à la mort subite")
+		return
+	}
+	if _, done := r.answers[userID]; done {
+		return
+	}
+	r.answers[userID] = payload.Choice
+}
+
+func (r *SuddenDeathRound) Tick(ctx *RoundContext, now time.Time) bool {
+	// Annonce ou question terminée : la suivante après la pause, sauf si le départage est joué
+	if !r.closedAt.IsZero() {
+		delay := suddenDeathRevealDelay
+		if r.current < 0 {
+			delay = suddenDeathIntro
+		}
+		if now.Sub(r.closedAt) < delay {
+			return false
+		}
+		if (r.current >= 0 && len(r.remaining) <= 1) || r.current+1 >= len(r.Questions) {
+			return true
+		}
+		r.current++
+		r.sendCurrent(ctx, now)
+		return false
+	}
+
+	allAnswered := true
+	for _, id := range r.remaining {
+		if _, ok := ctx.Game.Players[id]; !ok {
+			continue
+		}
+		if _, ok := r.answers[id]; !ok {
+			allAnswered = false
+			break
+		}
+	}
+	if allAnswered || !now.Before(r.deadline) {
+		r.resolve(ctx, now)
+	}
+	return false
+}
+
+// resolve écarte les joueurs qui se sont trompés si au moins un adversaire a trouvé
+func (r *SuddenDeathRound) resolve(ctx *RoundContext, now time.Time) {
+	q := r.Questions[r.current]
+	var right, wrong []int
+	for _, id := range r.remaining {
+		choice, answered := r.answers[id]
+		correct := answered && isCorrectChoice(q, choice)
+		if correct {
+			right = append(right, id)
+		} else {
+			wrong = append(wrong, id)
+		}
+		if answered {
+			ctx.RecordAnswer(shared.TranscriptAnswer{
+				QuestionID: q.ID,
+				UserID:     id,
+				Kind:       shared.ActionAnswer,
+				Answer:     choiceLetter(choice),
+				Correct:    correct,
+			}, r.askedAt)
+		}
+	}
+	if len(right) > 0 && len(wrong) > 0 {
+		r.remaining = right
+		r.out = append(r.out, wrong)
+		log.Printf("⚡ Mort subite : %v écarté(s), %v encore en course", wrong, right)
+	}
+	r.closedAt = now
+	ctx.Broadcast(r.statusMessage(ctx, q.CorrectAnswer))
+}
+
+// Finish ordonne les joueurs départagés : les joueurs encore en course, puis les
+// écartés du dernier au premier. Chaque groupe prend le rang qu'il obtient parmi les
+// joueurs départagés (0, 1, 1, 3...), ajouté à leur départage précédent.
+func (r *SuddenDeathRound) Finish(ctx *RoundContext) {
+	game := ctx.Game
+	tiers := [][]int{r.remaining}
+	for i := len(r.out) - 1; i >= 0; i-- {
+		tiers = append(tiers, r.out[i])
+	}
+	rank := 0
+	for _, tier := range tiers {
+		for _, id := range tier {
+			game.TieBreaks[id] += rank
+		}
+		rank += len(tier)
+	}
+	r.end(game)
+}
+
+// suddenDeathState est l'état sauvegardé d'une mort subite
+type suddenDeathState struct {
+	baseState
+	Questions       []shared.Question `json:"questions"`
+	TimePerQuestion time.Duration     `json:"time_per_question"`
+	Place           int               `json:"place"`
+	Players         []int             `json:"players"`
+	Current         int               `json:"current"`
+	AskedAt         time.Time         `json:"asked_at"`
+	Deadline        time.Time         `json:"deadline"`
+	ClosedAt        time.Time         `json:"closed_at"`
+	Remaining       []int             `json:"remaining"`
+	Out             [][]int           `json:"out"`
+	Answers         map[int]int       `json:"answers"`
+}
+
+func (r *SuddenDeathRound) SaveState() (json.RawMessage, error) {
+	return json.Marshal(suddenDeathState{
+		baseState:       r.saveBase(),
+		Questions:       r.Questions,
+		TimePerQuestion: r.TimePerQuestion,
+		Place:           r.Place,
+		Players:         r.Players,
+		Current:         r.current,
+		AskedAt:         r.askedAt,
+		Deadline:        r.deadline,
+		ClosedAt:        r.closedAt,
+		Remaining:       r.remaining,
+		Out:             r.out,
+		Answers:         r.answers,
+	})
+}
+
+func (r *SuddenDeathRound) LoadState(data json.RawMessage, offset time.Duration) error {
+	var s suddenDeathState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	r.loadBase(s.baseState)
+	r.Questions = s.Questions
+	r.TimePerQuestion = s.TimePerQuestion
+	r.Place = s.Place
+	r.Players = s.Players
+	r.current = s.Current
+	r.askedAt = shiftTime(s.AskedAt, offset)
+	r.deadline = shiftTime(s.Deadline, offset)
+	r.closedAt = shiftTime(s.ClosedAt, offset)
+	r.remaining = s.Remaining
+	r.out = s.Out
+	r.answers = orEmpty(s.Answers)
+	return nil
+}
+
+func (r *SuddenDeathRound) Resend(ctx *RoundContext, userID int) {
+	ctx.SendTo(userID, r.statusMessage(ctx, ""))
+	if r.current >= 0 && r.closedAt.IsZero() {
+		if _, answered := r.answers[userID]; !answered {
+			ctx.SendTo(userID, questionMessage(r.Questions[r.current], 1))
+		}
+	}
+}
+
+// ===== DÉPARTAGE EN FIN DE PARTIE =====
+
+// tieBreakPlaces renvoie le nombre de places du classement départagées par mort
+// subite (0 en mode équipes ou élimination, qui ont leur propre classement).
+// L'appelant doit détenir game.Mutex.
+func (game *Game) tieBreakPlaces() int {
+	if game.teamsEnabled() || game.eliminationEnabled() {
+		return 0
+	}
+	switch game.Settings.TieBreak {
+	case shared.TieBreakFirst:
+		return 1
+	case shared.TieBreakPodium:
+		return 3
+	}
+	return 0
+}
+
+// tiedGroup renvoie la première place à partir de from partagée par plusieurs
+// joueurs, parmi les places départagées, et les joueurs concernés (nil sinon).
+// L'appelant doit détenir game.Mutex.
+func (game *Game) tiedGroup(from int) ([]int, int) {
+	limit := game.tieBreakPlaces()
+	if len(game.Players) < 2 {
+		return nil, 0
+	}
+	places := game.placements()
+	for place := from; place <= limit; place++ {
+		var ids []int
+		for id, p := range places {
+			if p == place {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) >= 2 {
+			sort.Ints(ids)
+			return ids, place
+		}
+	}
+	return nil, 0
+}
+
+// breakTies départage par mort subite les joueurs à égalité sur les places
+// concernées, de la première à la dernière, avant le classement final. Une place
+// qui reste partagée (questions épuisées) n'est pas rejouée.
+func (gm *GameManager) breakTies(conn *net.UDPConn, game *Game) error {
+	for from := 1; ; {
+		game.Mutex.Lock()
+		if game.StopReason != "" {
+			game.Mutex.Unlock()
+			return nil
+		}
+		group, place := game.tiedGroup(from)
+		if len(group) == 0 {
+			game.Mutex.Unlock()
+			return nil
+		}
+		round := NewSuddenDeathRound()
+		round.Place = place
+		round.Players = group
+		if err := round.Prepare(game); err != nil {
+			log.Printf("⚠️ Partie %s - mort subite impossible, égalité conservée: %v", game.Code, err)
+			game.Mutex.Unlock()
+			return nil
+		}
+		game.Rounds = append(game.Rounds, round)
+		index := len(game.Rounds) - 1
+		game.Mutex.Unlock()
+
+		// Le classement de la dernière manche reste affiché avant l'annonce
+		time.Sleep(revealDuration)
+		if err := gm.runRound(conn, game, index, round); err != nil {
+			return err
+		}
+		if err := gm.setState(conn, game, shared.GameStateReveal, ""); err != nil {
+			return err
+		}
+		from = place + 1
+	}
+}
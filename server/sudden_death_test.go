@@ -0,0 +1,47 @@
+package server
+
+import (
+	"quiz-app-fyne/shared"
+	"reflect"
+	"testing"
+)
+
+func TestTiedGroup(t *testing.T) {
+	tests := []struct {
+		name        string
+		tieBreak    string
+		elimination bool
+		scores      map[int]int
+		tieBreaks   map[int]int
+		from        int
+		want        []int
+		wantPlace   int
+	}{
+		{"égalité en tête", shared.TieBreakFirst, false, map[int]int{1: 20, 2: 20, 3: 10}, nil, 1, []int{1, 2}, 1},
+		{"égalité hors de la première place", shared.TieBreakFirst, false, map[int]int{1: 30, 2: 20, 3: 20}, nil, 1, nil, 0},
+		{"égalité en deuxième place du podium", shared.TieBreakPodium, false, map[int]int{1: 30, 2: 20, 3: 20}, nil, 1, []int{2, 3}, 2},
+		{"égalité en troisième place du podium", shared.TieBreakPodium, false, map[int]int{1: 30, 2: 20, 3: 10, 4: 10}, nil, 1, []int{3, 4}, 3},
+		{"égalité hors du podium", shared.TieBreakPodium, false, map[int]int{1: 30, 2: 20, 3: 10, 4: 5, 5: 5}, nil, 1, nil, 0},
+		{"places déjà départagées", shared.TieBreakPodium, false, map[int]int{1: 20, 2: 20, 3: 20}, nil, 2, nil, 0},
+		{"mort subite jouée", shared.TieBreakFirst, false, map[int]int{1: 20, 2: 20}, map[int]int{1: 2, 2: 1}, 1, nil, 0},
+		{"mort subite sans vainqueur", shared.TieBreakPodium, false, map[int]int{1: 20, 2: 20, 3: 20}, map[int]int{1: 1, 2: 2, 3: 2}, 1, []int{2, 3}, 2},
+		{"sans départage", shared.TieBreakNone, false, map[int]int{1: 20, 2: 20}, nil, 1, nil, 0},
+		{"mode élimination", shared.TieBreakFirst, true, map[int]int{1: 20, 2: 20}, nil, 1, nil, 0},
+		{"joueur seul", shared.TieBreakFirst, false, map[int]int{1: 20}, nil, 1, nil, 0},
+	}
+	for _, tt := range tests {
+		game := &Game{
+			Settings:  shared.GameSettings{TieBreak: tt.tieBreak, Elimination: tt.elimination},
+			Players:   make(map[int]*shared.User),
+			Scores:    tt.scores,
+			TieBreaks: tt.tieBreaks,
+		}
+		for id := range tt.scores {
+			game.Players[id] = &shared.User{ID: id}
+		}
+		got, place := game.tiedGroup(tt.from)
+		if !reflect.DeepEqual(got, tt.want) || place != tt.wantPlace {
+			t.Errorf("%s : tiedGroup(%d) = %v, %d, attendu %v, %d", tt.name, tt.from, got, place, tt.want, tt.wantPlace)
+		}
+	}
+}
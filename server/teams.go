@@ -0,0 +1,215 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"quiz-app-fyne/shared"
+	"sort"
+)
+
+// teamsEnabled indique si la partie se joue par équipes.
+// L'appelant doit détenir game.Mutex.
+func (game *Game) teamsEnabled() bool {
+	return game.Settings.Teams > 0
+}
+
+// teamSizes compte les joueurs de chaque équipe (index 1 à Settings.Teams).
+// L'appelant doit détenir game.Mutex.
+func (game *Game) teamSizes() []int {
+	sizes := make([]int, game.Settings.Teams+1)
+	for id := range game.Players {
+		if team := game.Teams[id]; team > 0 && team <= game.Settings.Teams {
+			sizes[team]++
+		}
+	}
+	return sizes
+}
+
+// assignTeam place un joueur sans équipe dans l'équipe la moins nombreuse.
+// L'appelant doit détenir game.Mutex.
+func (game *Game) assignTeam(userID int) {
+	if !game.teamsEnabled() {
+		return
+	}
+	if team := game.Teams[userID]; team > 0 && team <= game.Settings.Teams {
+		return
+	}
+	sizes := game.teamSizes()
+	best := 1
+	for team := 2; team <= game.Settings.Teams; team++ {
+		if sizes[team] < sizes[best] {
+			best = team
+		}
+	}
+	game.Teams[userID] = best
+}
+
+// balanceTeams répartit tous les joueurs selon leur classement : du plus fort au
+// plus faible, chacun rejoint l'équipe la moins nombreuse puis la moins bien classée.
+// L'appelant doit détenir game.Mutex.
+func (game *Game) balanceTeams() {
+	if !game.teamsEnabled() {
+		return
+	}
+	players := make([]*shared.User, 0, len(game.Players))
+	for _, player := range game.Players {
+		players = append(players, player)
+	}
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].Rating != players[j].Rating {
+			return players[i].Rating > players[j].Rating
+		}
+		return players[i].ID < players[j].ID
+	})
+
+	sizes := make([]int, game.Settings.Teams+1)
+	ratings := make([]int, game.Settings.Teams+1)
+	for _, player := range players {
+		best := 1
+		for team := 2; team <= game.Settings.Teams; team++ {
+			if sizes[team] < sizes[best] || (sizes[team] == sizes[best] && ratings[team] < ratings[best]) {
+				best = team
+			}
+		}
+		game.Teams[player.ID] = best
+		sizes[best]++
+		ratings[best] += player.Rating
+	}
+}
+
+// prepareTeams vérifie la composition des équipes au lancement : chaque joueur a
+// une équipe et au moins deux équipes sont représentées, sinon elles sont rééquilibrées.
+// L'appelant doit détenir game.Mutex.
+func (game *Game) prepareTeams() {
+	if !game.teamsEnabled() {
+		return
+	}
+	for id := range game.Players {
+		game.assignTeam(id)
+	}
+	filled := 0
+	for _, size := range game.teamSizes()[1:] {
+		if size > 0 {
+			filled++
+		}
+	}
+	if filled < 2 && len(game.Players) >= 2 {
+		game.balanceTeams()
+		log.Printf("⚖️ Partie %s - équipes rééquilibrées au lancement", game.Code)
+	}
+}
+
+// teamScore calcule le score d'une équipe à partir des scores de ses membres
+func teamScore(scores []int, rule string) int {
+	if len(scores) == 0 {
+		return 0
+	}
+	switch rule {
+	case shared.TeamScoreBest:
+		best := scores[0]
+		for _, s := range scores[1:] {
+			if s > best {
+				best = s
+			}
+		}
+		return best
+	case shared.TeamScoreAverage:
+		total := 0
+		for _, s := range scores {
+			total += s
+		}
+		return int(math.Round(float64(total) / float64(len(scores))))
+	default:
+		total := 0
+		for _, s := range scores {
+			total += s
+		}
+		return total
+	}
+}
+
+// teamStandings renvoie le classement des équipes qui ont encore des joueurs
+// (nil hors mode équipes). L'appelant doit détenir game.Mutex.
+func (game *Game) teamStandings() []shared.TeamScore {
+	if !game.teamsEnabled() {
+		return nil
+	}
+	members := make(map[int][]int)
+	for id := range game.Players {
+		if team := game.Teams[id]; team > 0 {
+			members[team] = append(members[team], id)
+		}
+	}
+
+	teams := []shared.TeamScore{}
+	for team := 1; team <= game.Settings.Teams; team++ {
+		ids := members[team]
+		if len(ids) == 0 {
+			continue
+		}
+		sort.Ints(ids)
+		scores := make([]int, len(ids))
+		for i, id := range ids {
+			scores[i] = game.Scores[id]
+		}
+		teams = append(teams, shared.TeamScore{
+			Team:    team,
+			Name:    shared.TeamName(team),
+			Score:   teamScore(scores, game.Settings.TeamScoring),
+			Members: ids,
+		})
+	}
+
+	sort.SliceStable(teams, func(i, j int) bool {
+		return teams[i].Score > teams[j].Score
+	})
+	for i := range teams {
+		if i > 0 && teams[i].Score == teams[i-1].Score {
+			teams[i].Rank = teams[i-1].Rank
+		} else {
+			teams[i].Rank = i + 1
+		}
+	}
+	return teams
+}
+
+// SetTeam permet à l'hôte de placer un joueur du lobby dans une équipe
+func (gm *GameManager) SetTeam(conn *net.UDPConn, code string, userID, targetID, team int) error {
+	game, err := gm.hostGame(code, userID)
+	if err != nil {
+		return err
+	}
+	defer game.Mutex.Unlock()
+
+	if !game.teamsEnabled() {
+		return fmt.Errorf("la partie ne se joue pas en équipes")
+	}
+	if _, ok := game.Players[targetID]; !ok {
+		return fmt.Errorf("joueur introuvable")
+	}
+	if team < 1 || team > game.Settings.Teams {
+		return fmt.Errorf("équipe inconnue")
+	}
+	game.Teams[targetID] = team
+	game.broadcastLobby(conn)
+	return nil
+}
+
+// BalanceTeams répartit à nouveau les joueurs du lobby selon leur classement
+func (gm *GameManager) BalanceTeams(conn *net.UDPConn, code string, userID int) error {
+	game, err := gm.hostGame(code, userID)
+	if err != nil {
+		return err
+	}
+	defer game.Mutex.Unlock()
+
+	if !game.teamsEnabled() {
+		return fmt.Errorf("la partie ne se joue pas en équipes")
+	}
+	game.balanceTeams()
+	game.broadcastLobby(conn)
+	log.Printf("⚖️ Partie %s - équipes équilibrées par l'hôte", code)
+	return nil
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// Limitation des tentatives JOIN_GAME échouées par adresse IP
+const (
+	MaxFailedJoins  = 5
+	FailedJoinDelay = time.Minute // Fenêtre de comptage et durée du blocage
+)
+
+type joinThrottle struct {
+	mutex    sync.Mutex
+	failures map[string][]time.Time
+}
+
+var JoinThrottle = &joinThrottle{
+	failures: make(map[string][]time.Time),
+}
+
+// recent renvoie les échecs encore dans la fenêtre. L'appelant doit détenir le verrou.
+func (t *joinThrottle) recent(ip string, now time.Time) []time.Time {
+	var kept []time.Time
+	for _, at := range t.failures[ip] {
+		if now.Sub(at) < FailedJoinDelay {
+			kept = append(kept, at)
+		}
+	}
+	if len(kept) == 0 {
+		delete(t.failures, ip)
+	} else {
+		t.failures[ip] = kept
+	}
+	return kept
+}
+
+// Allowed indique si l'adresse peut encore tenter de rejoindre une partie
+func (t *joinThrottle) Allowed(ip string) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return len(t.recent(ip, time.Now())) < MaxFailedJoins
+}
+
+// Fail enregistre une tentative échouée (code, mot de passe ou salle invalide)
+func (t *joinThrottle) Fail(ip string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	now := time.Now()
+	t.failures[ip] = append(t.recent(ip, now), now)
+}
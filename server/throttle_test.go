@@ -0,0 +1,35 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJoinThrottle(t *testing.T) {
+	throttle := &joinThrottle{failures: make(map[string][]time.Time)}
+
+	for i := 0; i < MaxFailedJoins; i++ {
+		if !throttle.Allowed("10.0.0.1") {
+			t.Fatalf("adresse bloquée après %d échecs", i)
+		}
+		throttle.Fail("10.0.0.1")
+	}
+	if throttle.Allowed("10.0.0.1") {
+		t.Errorf("adresse encore autorisée après %d échecs", MaxFailedJoins)
+	}
+	if !throttle.Allowed("10.0.0.2") {
+		t.Error("les échecs d'une adresse bloquent les autres")
+	}
+
+	// Les échecs sortis de la fenêtre ne comptent plus
+	old := time.Now().Add(-FailedJoinDelay)
+	for i := range throttle.failures["10.0.0.1"] {
+		throttle.failures["10.0.0.1"][i] = old
+	}
+	if !throttle.Allowed("10.0.0.1") {
+		t.Error("adresse toujours bloquée après le délai")
+	}
+	if _, ok := throttle.failures["10.0.0.1"]; ok {
+		t.Error("échecs expirés conservés")
+	}
+}
@@ -46,84 +46,316 @@ func HandleMessage(conn *net.UDPConn, addr *net.UDPAddr, data []byte) {
 			Payload: shared.LoginOKPayload{
 				UserID: loggedInUser.ID,
 				Email:  loggedInUser.Email,
+				Token:  Sessions.Open(loggedInUser.ID),
 			},
 		})
 
 	case shared.MsgCreateGame:
-		payload := msg.Payload.(map[string]interface{})
-		userID := int(payload["user_id"].(float64))
-		mode := payload["mode"].(string)
+		var payload shared.CreateGamePayload
+		if err := decodePayload(msg, &payload); err != nil {
+			SendGameError(conn, addr, "requête de création invalide")
+			return
+		}
+		mode := payload.Mode
 
-		user, _ := DB.GetUserByID(userID)
+		settings := shared.DefaultGameSettings()
+		if payload.Settings != nil {
+			settings = *payload.Settings
+		}
+		settings, err := ValidateSettings(settings, mode)
+		if err != nil {
+			SendGameError(conn, addr, err.Error())
+			return
+		}
+
+		user, err := DB.GetUserByID(payload.UserID)
+		if err != nil {
+			SendGameError(conn, addr, "utilisateur introuvable")
+			return
+		}
 		user.Addr = addr // ✅ TRÈS IMPORTANT
 
-		game := Manager.CreateGame(user)
+		game := Manager.CreateGame(user, settings, payload.Password, payload.Public && mode == "multi")
 		game.Mode = mode
 
 		SendResponse(conn, addr, shared.Message{
 			Type: shared.MsgCreateGame,
-			Payload: map[string]string{
-				"game_code": game.Code,
-				"mode":      mode,
+			Payload: shared.GameJoinedPayload{
+				GameCode: game.Code,
+				Mode:     mode,
+				HostID:   game.HostID,
+				Private:  game.PasswordHash != "",
+				Settings: settings,
 			},
 		})
 
 		if mode == "solo" {
-			Manager.StartGame(game.Code)
 			Manager.Conn = conn
+			if err := Manager.StartGame(game.Code); err != nil {
+				log.Println("❌ Impossible de démarrer la partie :", err)
+				Manager.AbandonGame(conn, game.Code, err.Error())
+				return
+			}
 			go Manager.RunGame(conn, game.Code)
 		}
 		if mode == "multi" {
-			Manager.MonitorLobby(game, conn)
+			Manager.updateLobby(conn, game)
 		}
 
 	case shared.MsgJoinGame:
-		payload := msg.Payload.(map[string]interface{})
-		userID := int(payload["user_id"].(float64))
-		gameCode := payload["game_code"].(string)
-		user, err := DB.GetUserByID(userID)
+		var payload shared.JoinGamePayload
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		gameCode := NormalizeGameCode(payload.GameCode)
+
+		ip := addr.IP.String()
+		if !JoinThrottle.Allowed(ip) {
+			log.Printf("⛔ Trop de tentatives JOIN_GAME depuis %s", ip)
+			SendGameError(conn, addr, "trop de tentatives, réessaie dans une minute")
+			return
+		}
+
+		user, err := DB.GetUserByID(payload.UserID)
 		if err != nil {
 			log.Println("⚠️ Utilisateur introuvable")
+			SendGameError(conn, addr, "utilisateur introuvable")
 			return
 		}
 		user.Addr = addr
-		game, err := Manager.JoinGame(gameCode, user)
+		game, err := Manager.JoinGame(gameCode, payload.Password, user)
 		if err != nil {
 			log.Println("⚠️ Impossible de rejoindre la partie:", err)
+			JoinThrottle.Fail(ip)
+			SendGameError(conn, addr, err.Error())
 			return
 		}
-		Manager.MonitorLobby(game, conn)
+
+		// Le joueur reçoit les paramètres choisis par l'hôte
+		SendResponse(conn, addr, shared.Message{
+			Type: shared.MsgJoinGame,
+			Payload: shared.GameJoinedPayload{
+				GameCode: game.Code,
+				Mode:     game.Mode,
+				HostID:   game.HostID,
+				Private:  game.PasswordHash != "",
+				Settings: game.Settings,
+			},
+		})
+		Manager.updateLobby(conn, game)
 		log.Printf("✅ Joueur %s a rejoint la partie %s", user.Email, gameCode)
 
 	case shared.MsgStartGame:
-		payload := msg.Payload.(map[string]interface{})
-		gameCode := payload["game_code"].(string)
-		err := Manager.StartGame(gameCode)
-		if err != nil {
+		var payload shared.StartGamePayload
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		if !checkSession(conn, addr, payload.UserID, payload.Token) {
+			return
+		}
+		if err := Manager.HostStartGame(conn, payload.GameCode, payload.UserID); err != nil {
 			log.Println("❌ Impossible de démarrer la partie :", err)
+			SendGameError(conn, addr, err.Error())
 			return
 		}
-		log.Println("🚀 Partie démarrée :", gameCode)
-		go Manager.RunGame(conn, gameCode)
+		log.Println("🚀 Partie démarrée :", payload.GameCode)
 
-	case shared.MsgAnswer:
-		payload := msg.Payload.(map[string]interface{})
-		userID := int(payload["user_id"].(float64))
-		questionID := int(payload["question_id"].(float64))
-		choice := int(payload["choice"].(float64))
-		Manager.ProcessAnswer(userID, questionID, choice)
+	case shared.MsgSetReady:
+		var payload shared.SetReadyPayload
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		if !checkSession(conn, addr, payload.UserID, payload.Token) {
+			return
+		}
+		if err := Manager.SetReady(conn, payload.GameCode, payload.UserID, payload.Ready); err != nil {
+			SendGameError(conn, addr, err.Error())
+		}
 
-	case shared.MsgRequestRiddleHint:
-		payload := msg.Payload.(map[string]interface{})
-		userID := int(payload["user_id"].(float64))
-		hintType := int(payload["hint_type"].(float64)) // 1 ou 2
-		Manager.SendRiddleHint(conn, userID, hintType, addr)
+	case shared.MsgKickPlayer:
+		var payload shared.HostActionPayload
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		if !checkSession(conn, addr, payload.UserID, payload.Token) {
+			return
+		}
+		if err := Manager.KickPlayer(conn, payload.GameCode, payload.UserID, payload.TargetID); err != nil {
+			SendGameError(conn, addr, err.Error())
+		}
 
-	case shared.MsgRiddleAnswer:
-		payload := msg.Payload.(map[string]interface{})
-		userID := int(payload["user_id"].(float64))
-		answer := payload["answer"].(string)
-		Manager.ProcessRiddleAnswer(userID, answer)
+	case shared.MsgLockRoom:
+		var payload shared.LockRoomPayload
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		if !checkSession(conn, addr, payload.UserID, payload.Token) {
+			return
+		}
+		if err := Manager.LockRoom(conn, payload.GameCode, payload.UserID, payload.Locked); err != nil {
+			SendGameError(conn, addr, err.Error())
+		}
+
+	case shared.MsgSetPublic:
+		var payload shared.SetPublicPayload
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		if !checkSession(conn, addr, payload.UserID, payload.Token) {
+			return
+		}
+		if err := Manager.SetPublic(conn, payload.GameCode, payload.UserID, payload.Public); err != nil {
+			SendGameError(conn, addr, err.Error())
+		}
+
+	case shared.MsgSetTeam:
+		var payload shared.SetTeamPayload
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		if !checkSession(conn, addr, payload.UserID, payload.Token) {
+			return
+		}
+		if err := Manager.SetTeam(conn, payload.GameCode, payload.UserID, payload.TargetID, payload.Team); err != nil {
+			SendGameError(conn, addr, err.Error())
+		}
+
+	case shared.MsgBalanceTeams:
+		var payload shared.BalanceTeamsPayload
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		if !checkSession(conn, addr, payload.UserID, payload.Token) {
+			return
+		}
+		if err := Manager.BalanceTeams(conn, payload.GameCode, payload.UserID); err != nil {
+			SendGameError(conn, addr, err.Error())
+		}
+
+	case shared.MsgQueue:
+		var payload shared.QueuePayload
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		user, err := DB.GetUserByID(payload.UserID)
+		if err != nil {
+			SendGameError(conn, addr, "utilisateur introuvable")
+			return
+		}
+		user.Addr = addr
+		if err := Matchmaker.Enqueue(conn, user); err != nil {
+			SendGameError(conn, addr, err.Error())
+		}
+
+	case shared.MsgUnqueue:
+		var payload shared.QueuePayload
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		Matchmaker.Dequeue(conn, payload.UserID, addr)
+
+	case shared.MsgResume:
+		var payload shared.ResumePayload
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		Manager.ResumePlayer(conn, payload.UserID, payload.Token, addr)
+
+	case shared.MsgGameHistory:
+		var payload shared.GameHistoryRequestPayload
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		if !checkSession(conn, addr, payload.UserID, payload.Token) {
+			return
+		}
+		games, err := DB.GetUserGames(payload.UserID, HistoryLimit)
+		if err != nil {
+			log.Println("❌ Erreur lecture historique:", err)
+			SendGameError(conn, addr, "historique indisponible")
+			return
+		}
+		SendResponse(conn, addr, shared.Message{
+			Type:    shared.MsgGameHistory,
+			Payload: shared.GameHistoryPayload{Games: games},
+		})
+
+	case shared.MsgReplay:
+		var payload shared.ReplayRequestPayload
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		if !checkSession(conn, addr, payload.UserID, payload.Token) {
+			return
+		}
+		replay, err := GetReplay(payload.UserID, payload.GameID)
+		if err != nil {
+			SendGameError(conn, addr, err.Error())
+			return
+		}
+		if err := SendReplay(conn, addr, *replay); err != nil {
+			log.Printf("❌ Replay %s pour le joueur %d: %v", payload.GameID, payload.UserID, err)
+			SendGameError(conn, addr, err.Error())
+		}
+
+	case shared.MsgListRooms:
+		SendResponse(conn, addr, shared.Message{
+			Type:    shared.MsgListRooms,
+			Payload: shared.RoomListPayload{Rooms: Manager.ListRooms()},
+		})
+
+	case shared.MsgTransferHost:
+		var payload shared.HostActionPayload
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		if !checkSession(conn, addr, payload.UserID, payload.Token) {
+			return
+		}
+		if err := Manager.TransferHost(conn, payload.GameCode, payload.UserID, payload.TargetID); err != nil {
+			SendGameError(conn, addr, err.Error())
+		}
+
+	case shared.MsgLeaveGame:
+		var payload shared.LeaveGamePayload
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		if !checkSession(conn, addr, payload.UserID, payload.Token) {
+			return
+		}
+		if err := Manager.LeaveGame(conn, payload.GameCode, payload.UserID); err != nil {
+			SendGameError(conn, addr, err.Error())
+		}
+
+	case shared.MsgShortenCountdown:
+		var payload shared.ShortenCountdownPayload
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		if !checkSession(conn, addr, payload.UserID, payload.Token) {
+			return
+		}
+		if err := Manager.ShortenCountdown(payload.GameCode, payload.UserID, payload.Seconds); err != nil {
+			SendGameError(conn, addr, err.Error())
+		}
+
+	case shared.MsgPong:
+		var payload shared.PongPayload
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		Latency.Pong(payload.UserID, payload.Seq)
+
+	case shared.MsgAnswer, shared.MsgRequestRiddleHint, shared.MsgRiddleAnswer, shared.MsgUsePowerUp, shared.MsgPlaceWager:
+		// Messages traités par la manche en cours, qui décode le reste du payload
+		var payload struct {
+			UserID int `json:"user_id"`
+		}
+		if err := decodePayload(msg, &payload); err != nil {
+			return
+		}
+		Manager.HandleRoundMessage(conn, payload.UserID, msg)
 
 	default:
 		log.Println("⚠️ Type de message inconnu :", msg.Type)
@@ -131,6 +363,24 @@ func HandleMessage(conn *net.UDPConn, addr *net.UDPAddr, data []byte) {
 
 }
 
+// checkSession vérifie le jeton de session joint à une requête et signale un refus au client
+func checkSession(conn *net.UDPConn, addr *net.UDPAddr, userID int, token string) bool {
+	if Sessions.Check(userID, token) {
+		return true
+	}
+	log.Printf("⛔ Jeton de session refusé pour le joueur %d depuis %s", userID, addr)
+	SendGameError(conn, addr, "session expirée, reconnecte-toi")
+	return false
+}
+
+// SendGameError signale au client qu'une action sur une partie a été refusée
+func SendGameError(conn *net.UDPConn, addr *net.UDPAddr, message string) {
+	SendResponse(conn, addr, shared.Message{
+		Type:    shared.MsgGameError,
+		Payload: shared.GameErrorPayload{Message: message},
+	})
+}
+
 // SendResponse envoie un message UDP au client
 func SendResponse(conn *net.UDPConn, addr *net.UDPAddr, msg shared.Message) {
 	data, _ := json.Marshal(msg)
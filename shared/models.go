@@ -15,6 +15,7 @@ type User struct {
 	PasswordHash string       `json:"password_hash"`
 	TotalScore   int          `json:"total_score"`
 	GamesPlayed  int          `json:"games_played"`
+	Rating       int          `json:"rating"` // Classement Elo (parties multijoueurs)
 	CreatedAt    time.Time    `json:"created_at"`
 	LastLogin    *time.Time   `json:"last_login"`
 	Addr         *net.UDPAddr `json:"-"`         // Adresse UDP du joueur (non sérialisée en JSON)
@@ -41,10 +42,174 @@ type Question struct {
 // DEVINETTE (Riddle)
 // =====================
 type Riddle struct {
-	ID              int    `json:"id"`
-	RiddleText      string `json:"riddle_text"`
-	CorrectWord     string `json:"correct_word"`
-	HintLevel1      string `json:"hint_level1"`
-	HintLevel2      string `json:"hint_level2"`
-	DifficultyLevel int    `json:"difficulty_level"`
+	ID              int      `json:"id"`
+	RiddleText      string   `json:"riddle_text"`
+	CorrectWord     string   `json:"correct_word"`
+	HintLevel1      string   `json:"hint_level1"`
+	HintLevel2      string   `json:"hint_level2"`
+	DifficultyLevel int      `json:"difficulty_level"`
+	AcceptedAnswers []string `json:"accepted_answers"` // CorrectWord et ses variantes acceptées
+}
+
+// =====================
+// PARAMETRES DE PARTIE
+// =====================
+type GameSettings struct {
+	MaxPlayers        int      `json:"max_players"`         // Capacité de la salle
+	Rounds            []string `json:"rounds"`              // Manches jouées dans l'ordre (RoundQCM, RoundTimeAttack, RoundRiddle)
+	QuestionsPerRound int      `json:"questions_per_round"` // Nombre de questions d'une manche QCM
+	DifficultyMix     []int    `json:"difficulty_mix"`      // Pourcentage de questions de niveau 1, 2 et 3
+	Categories        []string `json:"categories"`          // Catégories autorisées (vide = toutes)
+	TimePerQuestion   int      `json:"time_per_question"`   // Secondes par question QCM
+	HintCosts         []int    `json:"hint_costs"`          // Coût des indices 1 et 2 de la devinette
+	HintBudget        int      `json:"hint_budget"`         // Points dépensables en indices par joueur (0 = illimité)
+	TypoTolerance     int      `json:"typo_tolerance"`      // Fautes de frappe tolérées dans la réponse à la devinette
+	RiddleAttempts    int      `json:"riddle_attempts"`     // Nombre d'essais par joueur pour la devinette
+	PowerUps          int      `json:"power_ups"`           // Utilisations de chaque joker par joueur et par partie (0 = sans jokers)
+	// Combos : paliers par série croissante (vide = sans multiplicateur)
+	StreakMultipliers []StreakMultiplier `json:"streak_multipliers"`
+	// Égalités départagées par mort subite en fin de partie (TieBreakNone, TieBreakFirst, TieBreakPodium)
+	TieBreak string `json:"tie_break,omitempty"`
+	// Abandon en cours de partie
+	ForfeitKeepScore  bool `json:"forfeit_keep_score"`  // Le score acquis est conservé
+	ForfeitCountsGame bool `json:"forfeit_counts_game"` // La partie est comptée comme jouée
+	// Mode équipes
+	Teams       int    `json:"teams"`                  // Nombre d'équipes (0 = chacun pour soi, 2 à 4)
+	TeamScoring string `json:"team_scoring,omitempty"` // Calcul du score d'équipe (TeamScoreSum, TeamScoreAverage, TeamScoreBest)
+	// Mode élimination : après chaque manche, les derniers deviennent spectateurs
+	Elimination       bool `json:"elimination"`
+	EliminatePerBlock int  `json:"eliminate_per_block,omitempty"` // Joueurs éliminés par manche (le duel final en élimine un)
+}
+
+// StreakMultiplier - Palier de combo : les bonnes réponses données après Streak
+// bonnes réponses consécutives rapportent Multiplier fois les points
+type StreakMultiplier struct {
+	Streak     int     `json:"streak"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+// Places départagées par mort subite
+const (
+	TieBreakNone   = "none"   // Les égalités sont conservées
+	TieBreakFirst  = "first"  // Égalité pour la première place
+	TieBreakPodium = "podium" // Égalités sur l'une des trois premières places
+)
+
+// Calcul du score d'une équipe à partir des scores de ses membres
+const (
+	TeamScoreSum     = "sum"
+	TeamScoreAverage = "average"
+	TeamScoreBest    = "best"
+)
+
+// TeamNames - Nom des équipes, dans l'ordre (équipe 1 = Rouges)
+var TeamNames = []string{"Rouges", "Bleus", "Verts", "Jaunes"}
+
+// TeamName renvoie le nom d'une équipe numérotée à partir de 1
+func TeamName(team int) string {
+	if team < 1 || team > len(TeamNames) {
+		return ""
+	}
+	return TeamNames[team-1]
+}
+
+// Jokers utilisables pendant les manches QCM
+const (
+	PowerUpFiftyFifty = "fifty_fifty" // Retire deux mauvaises réponses
+	PowerUpDouble     = "double"      // Double les points de la question en cours
+	PowerUpSkip       = "skip"        // Passe la question sans casser la série
+	PowerUpExtraTime  = "extra_time"  // Temps supplémentaire pour répondre
+)
+
+// PowerUpKinds - Jokers dans l'ordre d'affichage
+var PowerUpKinds = []string{PowerUpFiftyFifty, PowerUpDouble, PowerUpSkip, PowerUpExtraTime}
+
+// DefaultGameSettings renvoie les paramètres d'une partie classique
+func DefaultGameSettings() GameSettings {
+	return GameSettings{
+		MaxPlayers:        8,
+		Rounds:            []string{RoundQCM, RoundRiddle},
+		QuestionsPerRound: 8,
+		DifficultyMix:     []int{50, 50, 0},
+		Categories:        []string{},
+		TimePerQuestion:   10,
+		HintCosts:         []int{25, 50},
+		TypoTolerance:     1,
+		RiddleAttempts:    3,
+		PowerUps:          1,
+		StreakMultipliers: []StreakMultiplier{{Streak: 3, Multiplier: 1.5}, {Streak: 5, Multiplier: 2}},
+		TieBreak:          TieBreakFirst,
+		ForfeitKeepScore:  false,
+		ForfeitCountsGame: true,
+	}
+}
+
+// =====================
+// HISTORIQUE DES PARTIES
+// =====================
+
+// GameSummary résume une partie passée du point de vue d'un joueur
+type GameSummary struct {
+	GameID      string     `json:"game_id"`
+	Code        string     `json:"code"`
+	Mode        string     `json:"mode"`
+	StopReason  string     `json:"stop_reason"`
+	Players     int        `json:"players"`
+	StartedAt   time.Time  `json:"started_at"`
+	FinishedAt  *time.Time `json:"finished_at"` // nil si la partie n'est pas allée à son terme
+	Score       int        `json:"score"`
+	Placement   int        `json:"placement"`
+	RatingDelta int        `json:"rating_delta"`
+	Forfeit     bool       `json:"forfeit"`
+}
+
+// TranscriptPlayer - Participant d'une partie enregistrée
+type TranscriptPlayer struct {
+	UserID    int    `json:"user_id"`
+	Username  string `json:"username"`
+	Score     int    `json:"score"`
+	Placement int    `json:"placement"`
+	OldRating int    `json:"old_rating"`
+	NewRating int    `json:"new_rating"`
+	Forfeit   bool   `json:"forfeit"`
+}
+
+// TranscriptQuestion - Question (ou devinette) posée pendant une partie
+type TranscriptQuestion struct {
+	Round         int       `json:"round"` // Index de la manche (0 = première)
+	RoundName     string    `json:"round_name"`
+	QuestionID    int       `json:"question_id"`
+	Text          string    `json:"text"`
+	Options       []string  `json:"options,omitempty"` // Choix proposés (QCM)
+	CorrectAnswer string    `json:"correct_answer"`
+	AskedAt       time.Time `json:"asked_at"`
+}
+
+// Nature d'une action enregistrée
+const (
+	ActionAnswer  = "answer"   // Réponse à une question ou proposition pour la devinette
+	ActionHint    = "hint"     // Achat d'un indice
+	ActionPowerUp = "power_up" // Utilisation d'un joker (Answer = type de joker)
+)
+
+// TranscriptAnswer - Action d'un joueur pendant une partie
+type TranscriptAnswer struct {
+	Round      int       `json:"round"`
+	QuestionID int       `json:"question_id"`
+	UserID     int       `json:"user_id"`
+	Kind       string    `json:"kind"`   // ActionAnswer, ActionHint, ActionPowerUp
+	Answer     string    `json:"answer"` // Lettre choisie, texte proposé ou niveau d'indice
+	Correct    bool      `json:"correct"`
+	ResponseMs int       `json:"response_ms"` // Délai depuis l'envoi de la question
+	Points     int       `json:"points"`      // Points gagnés ou perdus
+	Score      int       `json:"score"`       // Score du joueur après l'action
+	AnsweredAt time.Time `json:"answered_at"`
+}
+
+// GameTranscript - Déroulé complet d'une partie enregistrée
+type GameTranscript struct {
+	Game      GameSummary          `json:"game"`
+	Players   []TranscriptPlayer   `json:"players"`
+	Questions []TranscriptQuestion `json:"questions"`
+	Answers   []TranscriptAnswer   `json:"answers"`
 }
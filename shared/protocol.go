@@ -17,6 +17,62 @@ const (
 	MsgRiddleHint        = "RIDDLE_HINT"
 	MsgRiddleAnswer      = "RIDDLE_ANSWER"
 	MsgRiddle            = "RIDDLE"
+	MsgRiddleResult      = "RIDDLE_RESULT"
+	MsgGameError         = "GAME_ERROR"
+	MsgGameState         = "GAME_STATE"
+	MsgCountdown         = "COUNTDOWN"
+	MsgShortenCountdown  = "SHORTEN_COUNTDOWN"
+	MsgLobbyUpdate       = "LOBBY_UPDATE"
+	MsgSetReady          = "SET_READY"
+	MsgKickPlayer        = "KICK_PLAYER"
+	MsgKicked            = "KICKED"
+	MsgLockRoom          = "LOCK_ROOM"
+	MsgTransferHost      = "TRANSFER_HOST"
+	MsgLeaveGame         = "LEAVE_GAME"
+	MsgSetPublic         = "SET_PUBLIC"
+	MsgListRooms         = "LIST_ROOMS"
+	MsgQueue             = "QUEUE"
+	MsgUnqueue           = "UNQUEUE"
+	MsgQueueStatus       = "QUEUE_STATUS"
+	MsgMatchFound        = "MATCH_FOUND"
+	MsgGameHistory       = "GAME_HISTORY"
+	MsgReplay            = "REPLAY"
+	MsgResume            = "RESUME"
+	MsgSetTeam           = "SET_TEAM"
+	MsgBalanceTeams      = "BALANCE_TEAMS"
+	MsgEliminated        = "ELIMINATED"
+	MsgBuzzerResult      = "BUZZER_RESULT"
+	MsgPing              = "PING"
+	MsgPong              = "PONG"
+	MsgUsePowerUp        = "USE_POWER_UP"
+	MsgPowerUpResult     = "POWER_UP_RESULT"
+	MsgAnswerResult      = "ANSWER_RESULT"
+	MsgWagerPrompt       = "WAGER_PROMPT"
+	MsgPlaceWager        = "PLACE_WAGER"
+	MsgWagerPlaced       = "WAGER_PLACED"
+	MsgWagerReveal       = "WAGER_REVEAL"
+	MsgSuddenDeath       = "SUDDEN_DEATH"
+)
+
+// États d'une partie
+const (
+	GameStateLobby     = "lobby"     // Salle ouverte, en attente de joueurs
+	GameStateCountdown = "countdown" // Compte à rebours avant le lancement
+	GameStateInRound   = "in_round"  // Manche en cours
+	GameStateReveal    = "reveal"    // Résultats entre deux manches
+	GameStateFinished  = "finished"  // Partie terminée normalement
+	GameStateAbandoned = "abandoned" // Partie interrompue
+)
+
+// Types de manches
+const (
+	RoundQCM        = "qcm"
+	RoundTimeAttack = "time_attack"
+	RoundRiddle     = "riddle"
+	RoundBuzzer     = "buzzer"
+	RoundWager      = "wager" // Manche finale à mise, forcément jouée en dernier
+	// Départage en fin de partie, lancé par le serveur (ne peut pas être choisi par l'hôte)
+	RoundSuddenDeath = "sudden_death"
 )
 
 // Message UDP générique
@@ -33,6 +89,7 @@ type LoginPayload struct {
 type LoginOKPayload struct {
 	UserID int    `json:"user_id"`
 	Email  string `json:"email"`
+	Token  string `json:"token"` // Jeton de session, joint aux requêtes qui engagent le compte
 }
 
 // QUESTION
@@ -45,6 +102,7 @@ type QuestionMessage struct {
 type QuestionPayload struct {
 	Question QuestionMessage `json:"question"`
 	Manche   int             `json:"manche"`
+	PowerUps map[string]int  `json:"power_ups,omitempty"` // Jokers restants du joueur (manche QCM)
 }
 
 // REPONSES
@@ -54,13 +112,163 @@ type AnswerPayload struct {
 	Choice     int `json:"choice"`
 }
 
+// AnswerResultPayload - Verdict d'une réponse QCM ou contre-la-montre, envoyé au joueur
+type AnswerResultPayload struct {
+	QuestionID int     `json:"question_id"`
+	Correct    bool    `json:"correct"`
+	Points     int     `json:"points"`
+	Streak     int     `json:"streak"`     // Série après cette réponse
+	Multiplier float64 `json:"multiplier"` // Multiplicateur de combo appliqué aux points
+}
+
 // MULTIJOUEUR
 type CreateGamePayload struct {
-	UserID int `json:"user_id"`
+	UserID   int           `json:"user_id"`
+	Mode     string        `json:"mode"`
+	Settings *GameSettings `json:"settings,omitempty"` // nil = paramètres par défaut
+	Password string        `json:"password,omitempty"` // Salle privée si renseigné
+	Public   bool          `json:"public,omitempty"`   // Salle visible dans LIST_ROOMS
 }
 type JoinGamePayload struct {
 	UserID   int    `json:"user_id"`
 	GameCode string `json:"game_code"`
+	Password string `json:"password,omitempty"`
+}
+type GameJoinedPayload struct {
+	GameCode string       `json:"game_code"`
+	Mode     string       `json:"mode"`
+	HostID   int          `json:"host_id"`
+	Private  bool         `json:"private"`
+	Settings GameSettings `json:"settings"`
+}
+
+// LOBBY
+type LobbyPlayer struct {
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+	Ready    bool   `json:"ready"`
+	IsHost   bool   `json:"is_host"`
+	Team     int    `json:"team,omitempty"` // Équipe du joueur (mode équipes)
+}
+type LobbyUpdatePayload struct {
+	GameCode string        `json:"game_code"`
+	HostID   int           `json:"host_id"`
+	State    string        `json:"state"`
+	Locked   bool          `json:"locked"`
+	Private  bool          `json:"private"`
+	Public   bool          `json:"public"`
+	Players  []LobbyPlayer `json:"players"`
+	Settings GameSettings  `json:"settings"`
+}
+
+// Les actions sur une salle portent le jeton de session reçu dans LOGIN_OK
+type StartGamePayload struct {
+	UserID   int    `json:"user_id"`
+	Token    string `json:"token"`
+	GameCode string `json:"game_code"`
+}
+type SetReadyPayload struct {
+	UserID   int    `json:"user_id"`
+	Token    string `json:"token"`
+	GameCode string `json:"game_code"`
+	Ready    bool   `json:"ready"`
+}
+type LockRoomPayload struct {
+	UserID   int    `json:"user_id"`
+	Token    string `json:"token"`
+	GameCode string `json:"game_code"`
+	Locked   bool   `json:"locked"`
+}
+
+type SetPublicPayload struct {
+	UserID   int    `json:"user_id"`
+	Token    string `json:"token"`
+	GameCode string `json:"game_code"`
+	Public   bool   `json:"public"`
+}
+
+// SetTeamPayload - L'hôte place un joueur dans une équipe
+type SetTeamPayload struct {
+	UserID   int    `json:"user_id"`
+	Token    string `json:"token"`
+	GameCode string `json:"game_code"`
+	TargetID int    `json:"target_id"`
+	Team     int    `json:"team"` // De 1 à Settings.Teams
+}
+
+// BalanceTeamsPayload - L'hôte demande une répartition équilibrée selon le classement
+type BalanceTeamsPayload struct {
+	UserID   int    `json:"user_id"`
+	Token    string `json:"token"`
+	GameCode string `json:"game_code"`
+}
+
+// RoomInfo décrit une salle publique dans la réponse à LIST_ROOMS
+type RoomInfo struct {
+	GameCode   string       `json:"game_code"`
+	HostName   string       `json:"host_name"`
+	Players    int          `json:"players"`
+	MaxPlayers int          `json:"max_players"`
+	Private    bool         `json:"private"`   // Mot de passe requis
+	Countdown  int          `json:"countdown"` // Secondes avant le lancement (0 = pas de compte à rebours)
+	Settings   GameSettings `json:"settings"`
+}
+type RoomListPayload struct {
+	Rooms []RoomInfo `json:"rooms"`
+}
+
+type QueuePayload struct {
+	UserID int `json:"user_id"`
+}
+type QueueStatusPayload struct {
+	InQueue   bool `json:"in_queue"`
+	Waiting   int  `json:"waiting"`    // Secondes passées dans la file
+	Queued    int  `json:"queued"`     // Joueurs en attente
+	Rating    int  `json:"rating"`     // Niveau utilisé pour l'appariement
+	RatingGap int  `json:"rating_gap"` // Écart de niveau accepté actuellement
+}
+
+// ResumePayload - Envoyé à la connexion pour retrouver une partie en cours
+type ResumePayload struct {
+	UserID int    `json:"user_id"`
+	Token  string `json:"token"` // Jeton reçu dans LOGIN_OK
+}
+
+type LeaveGamePayload struct {
+	UserID   int    `json:"user_id"`
+	Token    string `json:"token"`
+	GameCode string `json:"game_code"`
+}
+
+// Actions de l'hôte sur un autre joueur (KICK_PLAYER, TRANSFER_HOST)
+type HostActionPayload struct {
+	UserID   int    `json:"user_id"`
+	Token    string `json:"token"`
+	GameCode string `json:"game_code"`
+	TargetID int    `json:"target_id"`
+}
+
+// COMPTE A REBOURS DU LOBBY
+type CountdownPayload struct {
+	GameCode string `json:"game_code"`
+	Seconds  int    `json:"seconds"` // Secondes restantes avant le lancement
+}
+type ShortenCountdownPayload struct {
+	UserID   int    `json:"user_id"`
+	Token    string `json:"token"`
+	GameCode string `json:"game_code"`
+	Seconds  int    `json:"seconds"` // Nouveau délai souhaité par l'hôte
+}
+type GameStatePayload struct {
+	GameCode   string `json:"game_code"`
+	State      string `json:"state"`
+	Round      int    `json:"round"` // Numéro de la manche (à partir de 1), 0 hors partie
+	RoundCount int    `json:"round_count"`
+	RoundName  string `json:"round_name"`
+	Reason     string `json:"reason,omitempty"`
+}
+type GameErrorPayload struct {
+	Message string `json:"message"`
 }
 
 // DEVINETTE
@@ -68,22 +276,204 @@ type RiddlePayload struct {
 	RiddleID int    `json:"riddle_id"`
 	Text     string `json:"text"`
 }
+type RiddleHintRequestPayload struct {
+	UserID   int `json:"user_id"`
+	HintType int `json:"hint_type"` // 1 ou 2
+}
 type RiddleHintPayload struct {
 	RiddleID int    `json:"riddle_id"`
+	Level    int    `json:"level"`
 	Text     string `json:"text"`
-	Cost     int    `json:"cost"`
+	Cost     int    `json:"cost"` // 0 si l'indice avait déjà été acheté
 }
 type RiddleAnswerPayload struct {
 	UserID int    `json:"user_id"`
 	Answer string `json:"answer"`
 }
 
+// Verdicts d'une réponse à la devinette
+const (
+	RiddleCorrect = "correct"
+	RiddleClose   = "close"
+	RiddleWrong   = "wrong"
+	RiddleLocked  = "locked" // Déjà trouvée ou plus d'essais : réponse ignorée
+)
+
+type RiddleResultPayload struct {
+	RiddleID     int    `json:"riddle_id"`
+	Result       string `json:"result"` // RiddleCorrect, RiddleClose, RiddleWrong ou RiddleLocked
+	Points       int    `json:"points"`
+	AttemptsLeft int    `json:"attempts_left"`
+	Solved       bool   `json:"solved"`
+}
+
 // SCORES ET RESULTATS
 type PlayerResult struct {
-	UserID int    `json:"user_id"`
-	Email  string `json:"email"`
-	Score  int    `json:"score"`
+	UserID      int    `json:"user_id"`
+	Email       string `json:"email"`
+	Score       int    `json:"score"`
+	Rating      int    `json:"rating,omitempty"`       // Nouveau classement (parties multijoueurs)
+	RatingDelta int    `json:"rating_delta,omitempty"` // Variation du classement
+	Team        int    `json:"team,omitempty"`         // Équipe du joueur (mode équipes)
+	Placement   int    `json:"placement,omitempty"`    // Place finale, partagée par les ex æquo
 }
 type GameOverPayload struct {
 	Results []PlayerResult `json:"results"`
+	Teams   []TeamScore    `json:"teams,omitempty"` // Classement des équipes (mode équipes)
+}
+
+// TeamScore - Score d'une équipe, calculé selon Settings.TeamScoring
+type TeamScore struct {
+	Rank    int    `json:"rank"`
+	Team    int    `json:"team"`
+	Name    string `json:"name"`
+	Score   int    `json:"score"`
+	Members []int  `json:"members"` // Identifiants des joueurs de l'équipe
+}
+
+// CLASSEMENT EN DIRECT
+type ScoreEntry struct {
+	Rank       int     `json:"rank"`
+	UserID     int     `json:"user_id"`
+	Username   string  `json:"username"`
+	Score      int     `json:"score"`
+	Delta      int     `json:"delta"`                // Variation depuis le dernier classement envoyé
+	Streak     int     `json:"streak"`               // Bonnes réponses consécutives
+	Multiplier float64 `json:"multiplier,omitempty"` // Combo appliqué à la prochaine bonne réponse (si > 1)
+	Team       int     `json:"team,omitempty"`
+	Eliminated bool    `json:"eliminated,omitempty"` // Spectateur (mode élimination)
+}
+type ScoreUpdatePayload struct {
+	Scores []ScoreEntry `json:"scores"`
+	Teams  []TeamScore  `json:"teams,omitempty"` // Classement des équipes (mode équipes)
+}
+
+// BUZZER
+// BuzzerResultPayload - Diffusé à chaque réponse retenue d'une question buzzer
+type BuzzerResultPayload struct {
+	QuestionID int     `json:"question_id"`
+	UserID     int     `json:"user_id"` // Joueur qui a répondu (0 si personne n'a trouvé)
+	Username   string  `json:"username,omitempty"`
+	Correct    bool    `json:"correct"`
+	Points     int     `json:"points"`               // Points gagnés, ou pénalité (négative) d'une mauvaise réponse
+	Closed     bool    `json:"closed"`               // La question est terminée
+	Answer     string  `json:"answer,omitempty"`     // Bonne réponse (lettre), une fois la question terminée
+	Streak     int     `json:"streak,omitempty"`     // Série du gagnant
+	Multiplier float64 `json:"multiplier,omitempty"` // Combo appliqué aux points du gagnant
+}
+
+// JOKERS
+type UsePowerUpPayload struct {
+	UserID     int    `json:"user_id"`
+	QuestionID int    `json:"question_id"`
+	PowerUp    string `json:"power_up"` // PowerUpFiftyFifty, PowerUpDouble...
+}
+
+// PowerUpResultPayload - Effet d'un joker, envoyé au seul joueur qui l'a utilisé
+type PowerUpResultPayload struct {
+	QuestionID   int            `json:"question_id"`
+	PowerUp      string         `json:"power_up"`
+	Removed      []int          `json:"removed,omitempty"`       // 50/50 : choix retirés (0 à 3)
+	ExtraSeconds int            `json:"extra_seconds,omitempty"` // Temps supplémentaire accordé
+	Inventory    map[string]int `json:"inventory"`               // Jokers restants
+}
+
+// MISE FINALE
+// WagerPromptPayload - Invite un joueur à miser avant la question finale
+type WagerPromptPayload struct {
+	Category string `json:"category"` // Catégorie de la question, annoncée avant la mise
+	Level    int    `json:"level"`
+	MaxWager int    `json:"max_wager"` // Mise maximale du joueur
+	Seconds  int    `json:"seconds"`   // Temps pour miser
+}
+type PlaceWagerPayload struct {
+	UserID int `json:"user_id"`
+	Amount int `json:"amount"`
+}
+
+// WagerPlacedPayload - Confirme sa mise au seul joueur qui l'a placée
+type WagerPlacedPayload struct {
+	Amount int `json:"amount"`
+}
+
+// WagerRevealPayload - Mises et réponses de tous les joueurs, révélées à la fin de la manche
+type WagerRevealPayload struct {
+	QuestionID   int           `json:"question_id"`
+	Answer       string        `json:"answer"`         // Bonne réponse (lettre)
+	AnswerText   string        `json:"answer_text"`    // Texte de la bonne réponse
+	Reveals      []WagerReveal `json:"reveals"`        // Du plus petit au plus grand score final
+	RevealStepMs int           `json:"reveal_step_ms"` // Pause entre deux révélations à l'écran
+}
+type WagerReveal struct {
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+	Wager    int    `json:"wager"`
+	Answer   string `json:"answer,omitempty"` // Lettre choisie (vide sans réponse)
+	Correct  bool   `json:"correct"`
+	Points   int    `json:"points"` // Mise gagnée (positive) ou perdue (négative)
+	Score    int    `json:"score"`  // Score après la manche
+}
+
+// MORT SUBITE
+// SuddenDeathPayload - Annonce d'un départage entre joueurs à égalité, puis son
+// avancement après chaque question. Les autres joueurs regardent.
+type SuddenDeathPayload struct {
+	Place     int      `json:"place"`            // Place disputée
+	Players   []int    `json:"players"`          // Joueurs à départager
+	Names     []string `json:"names"`            // Pseudos des joueurs à départager
+	Remaining []int    `json:"remaining"`        // Joueurs encore en course
+	Answer    string   `json:"answer,omitempty"` // Bonne réponse de la question qui vient de se terminer
+	WinnerID  int      `json:"winner_id,omitempty"`
+}
+
+// MESURE DE LATENCE
+// PingPayload - Le client renvoie aussitôt un PONG avec le même numéro
+type PingPayload struct {
+	Seq int `json:"seq"`
+}
+type PongPayload struct {
+	UserID int `json:"user_id"`
+	Seq    int `json:"seq"`
+}
+
+// MODE ELIMINATION
+// EliminationPayload - Envoyé à la fin de chaque manche d'une partie à élimination
+type EliminationPayload struct {
+	Round      int      `json:"round"`               // Manche qui vient de se terminer (à partir de 1)
+	Eliminated []int    `json:"eliminated"`          // Joueurs éliminés, devenus spectateurs
+	Names      []string `json:"names"`               // Pseudos des joueurs éliminés
+	Remaining  int      `json:"remaining"`           // Joueurs encore en lice
+	WinnerID   int      `json:"winner_id,omitempty"` // Vainqueur, à l'issue du duel final
+}
+
+// HISTORIQUE ET REPLAY
+type GameHistoryRequestPayload struct {
+	UserID int    `json:"user_id"`
+	Token  string `json:"token"` // Jeton reçu dans LOGIN_OK
+}
+type GameHistoryPayload struct {
+	Games []GameSummary `json:"games"`
+}
+
+type ReplayRequestPayload struct {
+	UserID int    `json:"user_id"`
+	Token  string `json:"token"` // Jeton reçu dans LOGIN_OK
+	GameID string `json:"game_id"`
+}
+
+// ReplayStep - Une question de la partie, les actions des joueurs et les scores qui en résultent
+type ReplayStep struct {
+	Question TranscriptQuestion `json:"question"`
+	Answers  []TranscriptAnswer `json:"answers"` // Réponses et indices dans l'ordre d'arrivée
+	Scores   map[int]int        `json:"scores"`  // Score de chaque joueur après la question
+}
+
+// ReplayPayload - Replay d'une partie, envoyé en plusieurs parties (Part de 1 à Parts)
+// pour tenir dans des messages UDP : chaque partie porte une suite d'étapes
+type ReplayPayload struct {
+	Game    GameSummary        `json:"game"`
+	Players []TranscriptPlayer `json:"players"`
+	Steps   []ReplayStep       `json:"steps"`
+	Part    int                `json:"part,omitempty"`
+	Parts   int                `json:"parts,omitempty"`
 }